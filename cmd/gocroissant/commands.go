@@ -7,6 +7,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/beyondcivic/gocroissant/pkg/croissant"
 	"github.com/beyondcivic/gocroissant/pkg/version"
@@ -34,8 +35,8 @@ func versionCmd() *cobra.Command {
 func generateCmd() *cobra.Command {
 	var generateCmd = &cobra.Command{
 		Use:   "generate [csvPath]",
-		Short: "Generate Croissant metadata from a CSV file",
-		Long: `Generate Croissant metadata from a CSV file, automatically inferring data types 
+		Short: "Generate Croissant metadata from a CSV or Parquet file",
+		Long: `Generate Croissant metadata from a CSV or Parquet file, automatically inferring data types 
 		and creating a structured JSON-LD output that complies with the ML Commons Croissant specification.`,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -47,12 +48,12 @@ func generateCmd() *cobra.Command {
 
 			// Validate input file
 			if !fileExists(csvPath) {
-				fmt.Printf("Error: CSV file '%s' does not exist.\n", csvPath)
+				fmt.Printf("Error: Data file '%s' does not exist.\n", csvPath)
 				os.Exit(1)
 			}
 
-			if !isCSVFile(csvPath) {
-				fmt.Printf("Error: File '%s' does not appear to be a CSV file.\n", csvPath)
+			if !isCSVFile(csvPath) && !croissant.IsParquetFile(csvPath) {
+				fmt.Printf("Error: File '%s' does not appear to be a CSV or Parquet file.\n", csvPath)
 				os.Exit(1)
 			}
 
@@ -81,6 +82,7 @@ func generateCmd() *cobra.Command {
 			// Set validation options
 			if flagValidate || flagStrict || flagCheckFiles {
 				options := commonValidationCmd(flagStrict, flagCheckFiles, false)
+				options.BaseDir = filepath.Dir(csvPath)
 				metadata.ValidateWithOptions(options)
 
 				analyzeMetadataIssues(metadata.GetIssues())
@@ -108,6 +110,10 @@ func validateCmd() *cobra.Command {
 			strict, _ := cmd.Flags().GetBool("strict")
 			checkFiles, _ := cmd.Flags().GetBool("check-files")
 			checkUrls, _ := cmd.Flags().GetBool("check-urls")
+			checkData, _ := cmd.Flags().GetBool("check-data")
+			verifyChecksums, _ := cmd.Flags().GetBool("verify-checksums")
+			offline, _ := cmd.Flags().GetBool("offline")
+			strictRAI, _ := cmd.Flags().GetBool("strict-rai")
 
 			// Validate input file
 			if !fileExists(jsonldPath) {
@@ -125,6 +131,11 @@ func validateCmd() *cobra.Command {
 			}
 			// Set validation options
 			options := commonValidationCmd(strict, checkFiles, checkUrls)
+			options.BaseDir = filepath.Dir(jsonldPath)
+			options.CheckData = checkData
+			options.VerifyChecksums = verifyChecksums
+			options.Offline = offline
+			options.StrictRAI = strictRAI
 
 			issues, err := croissant.ValidateJSONWithOptions(data, options)
 			if err != nil {
@@ -136,8 +147,12 @@ func validateCmd() *cobra.Command {
 		},
 	}
 	validateCmd.Flags().Bool("strict", false, "Enable strict validation mode")
-	validateCmd.Flags().Bool("check-files", false, "Check if referenced files exist")
+	validateCmd.Flags().Bool("check-files", false, "Check if referenced files exist (relative to the metadata file)")
 	validateCmd.Flags().Bool("check-urls", false, "Validate URLs by making HTTP requests")
+	validateCmd.Flags().Bool("check-data", false, "Check that data values match their field types (relative to the metadata file)")
+	validateCmd.Flags().Bool("verify-checksums", false, "Recompute sha256, md5 and contentSize of local files and report mismatches")
+	validateCmd.Flags().Bool("offline", false, "Never fetch remote JSON-LD contexts (the Croissant 1.0 and 1.1 contexts are built in)")
+	validateCmd.Flags().Bool("strict-rai", false, "Warn about missing recommended Responsible AI (rai:) properties")
 
 	return validateCmd
 }
@@ -152,6 +167,7 @@ func infoCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			csvPath := args[0]
 			sampleSize, _ := cmd.Flags().GetInt("sample-size")
+			fullScan, _ := cmd.Flags().GetBool("full-scan")
 
 			if !fileExists(csvPath) {
 				fmt.Printf("Error: CSV file '%s' does not exist.\n", csvPath)
@@ -179,10 +195,25 @@ func infoCmd() *cobra.Command {
 			}
 
 			// Get column information with enhanced type detection
-			headers, columnTypes, err := croissant.GetCSVColumnTypes(csvPath, sampleSize)
-			if err != nil {
-				fmt.Printf("Error analyzing CSV: %v\n", err)
-				os.Exit(1)
+			var headers, columnTypes, columnNulls []string
+			if fullScan {
+				profiles, err := croissant.ProfileCSV(csvPath)
+				if err != nil {
+					fmt.Printf("Error analyzing CSV: %v\n", err)
+					os.Exit(1)
+				}
+				for _, profile := range profiles {
+					headers = append(headers, profile.Name)
+					columnTypes = append(columnTypes, profile.DataType)
+					columnNulls = append(columnNulls, fmt.Sprintf(", %d null(s)", profile.Nulls))
+				}
+			} else {
+				headers, columnTypes, err = croissant.GetCSVColumnTypes(csvPath, sampleSize)
+				if err != nil {
+					fmt.Printf("Error analyzing CSV: %v\n", err)
+					os.Exit(1)
+				}
+				columnNulls = make([]string, len(headers))
 			}
 
 			// Display information
@@ -192,21 +223,146 @@ func infoCmd() *cobra.Command {
 			fmt.Printf("Total Rows: %d (including header)\n", totalRows)
 			fmt.Printf("Data Rows: %d\n", totalRows-1)
 			fmt.Printf("Columns: %d\n", len(headers))
-			fmt.Printf("Sample Size: %d rows\n", sampleSize)
+			if fullScan {
+				fmt.Printf("Sample Size: all rows\n")
+			} else {
+				fmt.Printf("Sample Size: %d rows\n", sampleSize)
+			}
 			fmt.Println()
 
 			fmt.Printf("Column Information:\n")
 			fmt.Printf("-------------------\n")
 			for i, header := range headers {
-				fmt.Printf("%d. %s (%s)\n", i+1, header, columnTypes[i])
+				fmt.Printf("%d. %s (%s%s)\n", i+1, header, columnTypes[i], columnNulls[i])
 			}
 		},
 	}
 	infoCmd.Flags().Int("sample-size", 10, "Number of rows to sample for type inference")
+	infoCmd.Flags().Bool("full-scan", false, "Infer types from every row, and count null values, instead of a sample")
 
 	return infoCmd
 }
 
+// Load command - preview records described by a croissant jsonld file.
+func loadCmd() *cobra.Command {
+	var loadCmd = &cobra.Command{
+		Use:   "load [jsonldPath] [recordSet]",
+		Short: "Preview or export the records of a record set",
+		Long: `Read the records of a record set described by a Croissant metadata JSON-LD file and
+		print the first N of them as a table, JSON lines, or CSV. Relative content URLs are
+		resolved against the directory of the metadata file unless --base-dir is set.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			jsonldPath := args[0]
+			recordSetID := args[1]
+			limit, _ := cmd.Flags().GetInt("limit")
+			format, _ := cmd.Flags().GetString("format")
+			baseDir, _ := cmd.Flags().GetString("base-dir")
+
+			// Validate input file
+			if !fileExists(jsonldPath) {
+				fmt.Printf("Error: Metadata file '%s' does not exist.\n", jsonldPath)
+				os.Exit(1)
+			}
+
+			writer, err := newRecordWriter(format, os.Stdout)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			metadata, err := croissant.LoadMetadataFromFile(jsonldPath)
+			if err != nil {
+				fmt.Printf("Error loading metadata: %v\n", err)
+				os.Exit(1)
+			}
+
+			recordSet := croissant.FindRecordSet(*metadata, recordSetID)
+			if recordSet == nil {
+				fmt.Printf("Error: Record set '%s' not found in '%s'.\n", recordSetID, jsonldPath)
+				os.Exit(1)
+			}
+
+			options := croissant.DefaultRecordOptions()
+			options.BaseDir = baseDir
+			if options.BaseDir == "" {
+				options.BaseDir = filepath.Dir(jsonldPath)
+			}
+
+			if err := writeRecords(cmd.Context(), writer, *metadata, recordSetID, *recordSet, options, limit); err != nil {
+				fmt.Printf("Error reading records: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	loadCmd.Flags().IntP("limit", "n", 10, "Maximum number of records to print (0 for all)")
+	loadCmd.Flags().StringP("format", "f", "table", "Output format: table, jsonl, or csv")
+	loadCmd.Flags().String("base-dir", "", "Directory to resolve relative content URLs against (defaults to the metadata file's directory)")
+
+	return loadCmd
+}
+
+// Verify command - hash distribution files and compare them with their declared checksums.
+func verifyCmd() *cobra.Command {
+	var verifyCmd = &cobra.Command{
+		Use:   "verify [jsonldPath]",
+		Short: "Verify the checksums and sizes of distribution files",
+		Long: `Hash every local file of the distributions of a Croissant metadata JSON-LD file, including
+		the files of FileSets, and compare FileObjects with their declared sha256, md5 and contentSize.
+		Files are hashed concurrently. Relative content URLs are resolved against the directory of
+		the metadata file unless --base-dir is set. Remote files are skipped.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			jsonldPath := args[0]
+			workers, _ := cmd.Flags().GetInt("workers")
+			baseDir, _ := cmd.Flags().GetString("base-dir")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+
+			// Validate input file
+			if !fileExists(jsonldPath) {
+				fmt.Printf("Error: Metadata file '%s' does not exist.\n", jsonldPath)
+				os.Exit(1)
+			}
+
+			metadata, err := croissant.LoadMetadataFromFile(jsonldPath)
+			if err != nil {
+				fmt.Printf("Error loading metadata: %v\n", err)
+				os.Exit(1)
+			}
+
+			options := croissant.DefaultVerifyOptions()
+			if workers > 0 {
+				options.Workers = workers
+			}
+			options.BaseDir = baseDir
+			if options.BaseDir == "" {
+				options.BaseDir = filepath.Dir(jsonldPath)
+			}
+			if !quiet {
+				options.Progress = func(verified int, total int) {
+					fmt.Fprintf(os.Stderr, "\rVerifying files... %d/%d", verified, total)
+				}
+			}
+
+			results, err := croissant.VerifyDistributions(cmd.Context(), *metadata, options)
+			if !quiet {
+				fmt.Fprintln(os.Stderr)
+			}
+			if err != nil {
+				fmt.Printf("Error verifying files: %v\n", err)
+				os.Exit(1)
+			}
+
+			analyzeVerifications(results)
+		},
+	}
+	verifyCmd.Flags().IntP("workers", "w", 0, "Number of files to hash concurrently (defaults to the number of CPUs)")
+	verifyCmd.Flags().String("base-dir", "", "Directory to resolve relative content URLs against (defaults to the metadata file's directory)")
+	verifyCmd.Flags().BoolP("quiet", "q", false, "Do not show progress")
+
+	return verifyCmd
+}
+
 func matchCmd() *cobra.Command {
 	// Match command - compare two Croissant metadata files
 	var matchCmd = &cobra.Command{
@@ -353,3 +509,33 @@ func analyzeMetadataIssues(issues *croissant.Issues) {
 	// If there's only warnings, return a safe exit code.
 	os.Exit(0)
 }
+
+// Pretty prints file verification results for command output.
+// Does not return, calls os.Exit().
+func analyzeVerifications(results []croissant.FileVerification) {
+	verified, skipped, failed := 0, 0, 0
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Err != nil:
+			failed++
+			fmt.Printf("✗ %s: %v\n", result.Path, result.Err)
+		case len(result.Mismatches) > 0:
+			failed++
+			fmt.Printf("✗ %s:\n", result.Path)
+			for _, mismatch := range result.Mismatches {
+				fmt.Printf("    %s\n", mismatch)
+			}
+		default:
+			verified++
+		}
+	}
+
+	fmt.Printf("\nVerified %d file(s), %d failed, %d remote file(s) skipped.\n", verified, failed, skipped)
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
@@ -4,10 +4,12 @@
 // metadata format - a standardized way to describe machine learning datasets using JSON-LD.
 //
 // The command-line tool provides functionality to:
-//   - Generate Croissant metadata from CSV files with automatic type inference
+//   - Generate Croissant metadata from CSV and Parquet files with automatic type inference
 //   - Validate existing Croissant metadata files for specification compliance
 //   - Compare metadata files for schema compatibility
 //   - Analyze CSV file structure and display column information
+//   - Preview and export the records described by metadata files
+//   - Verify distribution files against their declared checksums and sizes
 //   - Display version and build information
 //
 // # Command Reference
@@ -36,6 +38,14 @@
 //
 //	gocroissant info data.csv --sample-size 20
 //
+// Preview the records of a record set:
+//
+//	gocroissant load metadata.jsonld main -n 20 --format jsonl
+//
+// Verify the checksums of the distribution files, hashing 8 files at a time:
+//
+//	gocroissant verify metadata.jsonld -w 8
+//
 // Show version information:
 //
 //	gocroissant version
@@ -106,6 +116,8 @@ func Init() {
 	RootCmd.AddCommand(generateCmd())
 	RootCmd.AddCommand(validateCmd())
 	RootCmd.AddCommand(infoCmd())
+	RootCmd.AddCommand(loadCmd())
+	RootCmd.AddCommand(verifyCmd())
 	RootCmd.AddCommand(matchCmd())
 }
 
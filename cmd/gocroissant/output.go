@@ -0,0 +1,155 @@
+// output.go
+// Formats materialized records for command output.
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/beyondcivic/gocroissant/pkg/croissant"
+)
+
+// recordWriter writes records in one of the supported output formats.
+type recordWriter interface {
+	WriteHeader(columns []string) error
+	WriteRecord(columns []string, record croissant.Record) error
+	Flush() error
+}
+
+// newRecordWriter returns a writer for the given output format.
+func newRecordWriter(format string, out io.Writer) (recordWriter, error) {
+	switch strings.ToLower(format) {
+	case "table":
+		return &tableRecordWriter{writer: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)}, nil
+	case "jsonl":
+		return &jsonlRecordWriter{encoder: json.NewEncoder(out)}, nil
+	case "csv":
+		return &csvRecordWriter{writer: csv.NewWriter(out)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format '%s' (expected table, jsonl, or csv)", format)
+	}
+}
+
+// writeRecords reads up to limit records of a record set, found by recordSetID, and writes them.
+// Row-level conversion errors are reported on stderr and do not stop the output.
+func writeRecords(ctx context.Context, writer recordWriter, metadata croissant.Metadata, recordSetID string, recordSet croissant.RecordSet, options croissant.RecordOptions, limit int) error {
+	columns := make([]string, 0, len(recordSet.Fields))
+	for _, field := range recordSet.Fields {
+		columns = append(columns, croissant.FieldKey(field))
+	}
+
+	if err := writer.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	count := 0
+	for record, err := range croissant.RecordsWithOptions(ctx, metadata, recordSetID, options) {
+		if record == nil {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if err := writer.WriteRecord(columns, record); err != nil {
+			return err
+		}
+
+		count++
+		if limit > 0 && count >= limit {
+			break
+		}
+	}
+
+	return writer.Flush()
+}
+
+// formatRecordValue renders a record value as text for table and CSV output.
+func formatRecordValue(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case []byte:
+		return fmt.Sprintf("<%d bytes>", len(typed))
+	case time.Time:
+		return typed.Format(time.RFC3339)
+	case fmt.Stringer:
+		return typed.String()
+	default:
+		if encoded, err := json.Marshal(typed); err == nil {
+			return string(encoded)
+		}
+		return fmt.Sprintf("%v", typed)
+	}
+}
+
+// tableRecordWriter writes records as an aligned text table.
+type tableRecordWriter struct {
+	writer *tabwriter.Writer
+}
+
+func (w *tableRecordWriter) WriteHeader(columns []string) error {
+	_, err := fmt.Fprintln(w.writer, strings.Join(columns, "\t"))
+	return err
+}
+
+func (w *tableRecordWriter) WriteRecord(columns []string, record croissant.Record) error {
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		// Keep cells on a single line so the table stays aligned.
+		cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(formatRecordValue(record[column]))
+	}
+	_, err := fmt.Fprintln(w.writer, strings.Join(cells, "\t"))
+	return err
+}
+
+func (w *tableRecordWriter) Flush() error {
+	return w.writer.Flush()
+}
+
+// jsonlRecordWriter writes one JSON object per record.
+type jsonlRecordWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlRecordWriter) WriteHeader(_ []string) error {
+	return nil
+}
+
+func (w *jsonlRecordWriter) WriteRecord(_ []string, record croissant.Record) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonlRecordWriter) Flush() error {
+	return nil
+}
+
+// csvRecordWriter writes records as CSV with a header row.
+type csvRecordWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvRecordWriter) WriteHeader(columns []string) error {
+	return w.writer.Write(columns)
+}
+
+func (w *csvRecordWriter) WriteRecord(columns []string, record croissant.Record) error {
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = formatRecordValue(record[column])
+	}
+	return w.writer.Write(cells)
+}
+
+func (w *csvRecordWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,231 @@
+// agents.go
+// Models and validates the persons and organizations that create and publish datasets.
+package croissant
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Types of Agent.
+const (
+	AgentPerson       = "sc:Person"
+	AgentOrganization = "sc:Organization"
+)
+
+// orcidPattern matches an ORCID iD, alone or as an orcid.org URL.
+var orcidPattern = regexp.MustCompile(`^(?:https?://(?:www\.)?orcid\.org/)?(\d{4}-\d{4}-\d{4}-\d{3}[\dX])$`) //nolint:gochecknoglobals
+
+// Agent represents a schema.org Person or Organization, such as a creator or publisher of a dataset.
+type Agent struct {
+	ID string `json:"@id,omitempty"`
+	// Type of the agent, AgentPerson or AgentOrganization.
+	Type string `json:"@type,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Email address, e.g. "jane@example.com".
+	Email string `json:"email,omitempty"`
+	// Url of the agent's webpage.
+	URL string `json:"url,omitempty"`
+	// Organization(s) a person is affiliated with.
+	Affiliation Agents `json:"affiliation,omitempty"`
+	// Identifier of the agent, e.g. an ORCID iD such as "https://orcid.org/0000-0002-1825-0097".
+	Identifier string `json:"identifier,omitempty"`
+	// Properties the struct does not model, e.g. "givenName" or "sameAs", by name.
+	// They are kept when decoding and emitted when encoding.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// NewPerson creates a Person with the given name.
+func NewPerson(name string) Agent {
+	return Agent{Type: AgentPerson, Name: name}
+}
+
+// NewOrganization creates an Organization with the given name.
+func NewOrganization(name string) Agent {
+	return Agent{Type: AgentOrganization, Name: name}
+}
+
+// IsPerson returns true if the agent is a Person.
+func (a Agent) IsPerson() bool {
+	return schemaOrgTypeName(a.Type) == "Person"
+}
+
+// IsOrganization returns true if the agent is an Organization.
+func (a Agent) IsOrganization() bool {
+	return schemaOrgTypeName(a.Type) == "Organization"
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Agent, keeping unmodelled properties in Extra.
+func (a *Agent) UnmarshalJSON(data []byte) error {
+	type plainAgent Agent
+	var plain plainAgent
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*a = Agent(plain)
+	a.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for Agent, including the properties in Extra.
+func (a Agent) MarshalJSON() ([]byte, error) {
+	type plainAgent Agent
+	return marshalWithExtra(plainAgent(a), a.Extra)
+}
+
+// Agents parses ONE or MANY Agents.
+type Agents []Agent
+
+// UnmarshalJSON implements custom JSON unmarshaling for Agents.
+//
+// Accepts:
+//   - "creator": { "@type": "sc:Person", "name": "..." }
+//   - "creator": [{ "@type": "sc:Person", "name": "..." }, { "@type": "sc:Organization", "name": "..." }...]
+//   - "creator": "..." (a name without type)
+func (a *Agents) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*a = []Agent{{Name: name}}
+
+		return nil
+	}
+
+	var single Agent
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = []Agent{single}
+
+		return nil
+	}
+
+	var multi []Agent
+	if err := json.Unmarshal(data, &multi); err == nil {
+		*a = multi
+
+		return nil
+	}
+
+	return CroissantError{
+		Message: "Agents: cannot unmarshal",
+		Value:   string(data),
+	}
+}
+
+// MarshalJSON implements custom JSON marshaling for Agents.
+func (a Agents) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+
+	return json.Marshal([]Agent(a))
+}
+
+// schemaOrgTypeName returns the name of a schema.org type, e.g. "Person" for
+// "sc:Person", "https://schema.org/Person" or "Person".
+func schemaOrgTypeName(typeName string) string {
+	for _, prefix := range []string{"sc:", "https://schema.org/", "http://schema.org/"} {
+		if name, found := strings.CutPrefix(typeName, prefix); found {
+			return name
+		}
+	}
+
+	return typeName
+}
+
+// validateAgents validates the creators or publishers of a dataset.
+func validateAgents(node *MetadataNode, property string, agents Agents, issues *Issues) {
+	for i, agent := range agents {
+		label := fmt.Sprintf("%s #%d", property, i+1)
+		if agent.Name != "" {
+			label = fmt.Sprintf("%s \"%s\"", property, agent.Name)
+		}
+		validateAgent(node, label, agent, issues)
+
+		for j, affiliation := range agent.Affiliation {
+			affiliationLabel := fmt.Sprintf("affiliation #%d of %s", j+1, label)
+			if affiliation.Name != "" {
+				affiliationLabel = fmt.Sprintf("affiliation \"%s\" of %s", affiliation.Name, label)
+			}
+			validateAgent(node, affiliationLabel, affiliation, issues)
+			if affiliation.IsPerson() {
+				issues.AddWarning(fmt.Sprintf("The %s should be an Organization, but is a Person.", affiliationLabel), node)
+			}
+		}
+	}
+}
+
+// validateAgent validates a single Person or Organization, described by label in issues.
+func validateAgent(node *MetadataNode, label string, agent Agent, issues *Issues) {
+	switch {
+	case agent.Type == "":
+		issues.AddWarning(fmt.Sprintf("The %s should have an attribute \"@type\": \"%s\" or \"@type\": \"%s\".", label, AgentPerson, AgentOrganization), node)
+	case !agent.IsPerson() && !agent.IsOrganization():
+		issues.AddError(fmt.Sprintf("The %s should have an attribute \"@type\": \"%s\" or \"@type\": \"%s\". Got %s instead.", label, AgentPerson, AgentOrganization, agent.Type), node)
+	}
+
+	if agent.Name == "" && agent.ID == "" {
+		issues.AddError(fmt.Sprintf("Property \"https://schema.org/name\" of the %s is mandatory, but does not exist.", label), node)
+	}
+
+	if agent.Email != "" && !isValidEmail(agent.Email) {
+		issues.AddError(fmt.Sprintf("The %s has an invalid email \"%s\".", label, agent.Email), node)
+	}
+
+	if agent.URL != "" && !isValidAbsoluteURL(agent.URL) {
+		issues.AddError(fmt.Sprintf("The %s has an invalid url \"%s\".", label, agent.URL), node)
+	}
+
+	if id, isORCID := parseORCID(agent.Identifier); isORCID && !isValidORCIDChecksum(id) {
+		issues.AddError(fmt.Sprintf("The %s has an invalid ORCID iD \"%s\": the checksum does not match.", label, agent.Identifier), node)
+	} else if !isORCID && strings.Contains(agent.Identifier, "orcid.org") {
+		issues.AddError(fmt.Sprintf("The %s has an invalid ORCID iD \"%s\".", label, agent.Identifier), node)
+	}
+}
+
+// isValidEmail returns true if email is a bare address, optionally with a "mailto:" scheme.
+func isValidEmail(email string) bool {
+	email = strings.TrimPrefix(email, "mailto:")
+	address, err := mail.ParseAddress(email)
+
+	return err == nil && address.Address == email
+}
+
+// isValidAbsoluteURL returns true if urlStr is an absolute http or https URL.
+func isValidAbsoluteURL(urlStr string) bool {
+	parsed, err := url.ParseRequestURI(urlStr)
+
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// parseORCID returns the 16 characters of an ORCID iD, e.g. "0000-0002-1825-0097",
+// if identifier is an ORCID iD or its URL.
+func parseORCID(identifier string) (string, bool) {
+	match := orcidPattern.FindStringSubmatch(identifier)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// isValidORCIDChecksum validates the check digit of an ORCID iD with ISO 7064 MOD 11-2.
+func isValidORCIDChecksum(id string) bool {
+	digits := strings.ReplaceAll(id, "-", "")
+	total := 0
+	for _, digit := range digits[:len(digits)-1] {
+		total = (total + int(digit-'0')) * 2
+	}
+	check := (12 - total%11) % 11
+
+	expected := byte('0' + check)
+	if check == 10 {
+		expected = 'X'
+	}
+
+	return digits[len(digits)-1] == expected
+}
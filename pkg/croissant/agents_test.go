@@ -0,0 +1,108 @@
+// File: pkg/croissant/agents_test.go
+package croissant
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseAgents(t *testing.T) {
+	document := `{
+		"@context": {"@vocab": "https://schema.org/", "sc": "https://schema.org/"},
+		"@type": "sc:Dataset",
+		"name": "cities",
+		"creator": [
+			{
+				"@type": "Person",
+				"name": "Jane Doe",
+				"email": "jane@example.com",
+				"identifier": "https://orcid.org/0000-0002-1825-0097",
+				"affiliation": {"@type": "Organization", "name": "Example University"},
+				"givenName": "Jane"
+			},
+			{"@type": "sc:Organization", "name": "Example Lab", "url": "https://example.com/lab"}
+		],
+		"publisher": {"@type": "Organization", "name": "Example Press"}
+	}`
+	metadata, err := NewJSONLDProcessor().ParseCroissantMetadata([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metadata.Creator) != 2 {
+		t.Fatalf("Creator = %+v, want 2 agents", metadata.Creator)
+	}
+	jane := metadata.Creator[0]
+	if !jane.IsPerson() || jane.Email != "jane@example.com" || jane.Identifier != "https://orcid.org/0000-0002-1825-0097" {
+		t.Errorf("Creator[0] = %+v", jane)
+	}
+	if len(jane.Affiliation) != 1 || !jane.Affiliation[0].IsOrganization() || jane.Affiliation[0].Name != "Example University" {
+		t.Errorf("Creator[0].Affiliation = %+v", jane.Affiliation)
+	}
+	if jane.Extra["givenName"] != "Jane" {
+		t.Errorf("Creator[0].Extra = %v, want givenName", jane.Extra)
+	}
+	if !metadata.Creator[1].IsOrganization() || metadata.Creator[1].URL != "https://example.com/lab" {
+		t.Errorf("Creator[1] = %+v", metadata.Creator[1])
+	}
+	if len(metadata.Publisher) != 1 || metadata.Publisher[0].Name != "Example Press" {
+		t.Errorf("Publisher = %+v", metadata.Publisher)
+	}
+
+	encoded, err := json.Marshal(metadata.Publisher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"@type":"sc:Organization","name":"Example Press"}`; string(encoded) != want {
+		t.Errorf("encoded publisher = %s, want %s", encoded, want)
+	}
+}
+
+func TestValidateAgents(t *testing.T) {
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt))
+	jane := NewPerson("Jane Doe")
+	jane.Email = "jane.example.com"
+	jane.Identifier = "https://orcid.org/0000-0002-1825-0098"
+	jane.Affiliation = Agents{NewPerson("John Doe")}
+	lab := NewOrganization("Example Lab")
+	lab.URL = "example.com/lab"
+	metadata.Creator = Agents{jane, lab, {Type: "sc:Place"}}
+
+	valid := NewPerson("Josiah Carberry")
+	valid.Email = "mailto:josiah@example.com"
+	valid.URL = "https://example.com/josiah"
+	valid.Identifier = "0000-0002-1694-233X"
+	metadata.Publisher = Agents{valid}
+
+	report := ValidateMetadata(metadata).Report()
+	for _, want := range []string{
+		`The creator "Jane Doe" has an invalid email "jane.example.com".`,
+		`The creator "Jane Doe" has an invalid ORCID iD "https://orcid.org/0000-0002-1825-0098": the checksum does not match.`,
+		`The affiliation "John Doe" of creator "Jane Doe" should be an Organization, but is a Person.`,
+		`The creator "Example Lab" has an invalid url "example.com/lab".`,
+		`The creator #3 should have an attribute "@type": "sc:Person" or "@type": "sc:Organization". Got sc:Place instead.`,
+		`Property "https://schema.org/name" of the creator #3 is mandatory, but does not exist.`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "publisher") {
+		t.Errorf("publisher is valid and should not be reported:\n%s", report)
+	}
+}
+
+func TestIsValidORCIDChecksum(t *testing.T) {
+	tests := map[string]bool{
+		"0000-0002-1825-0097": true,
+		"0000-0001-5109-3700": true,
+		"0000-0002-1694-233X": true,
+		"0000-0002-1825-0098": false,
+		"0000-0002-1694-2330": false,
+	}
+	for id, want := range tests {
+		if got := isValidORCIDChecksum(id); got != want {
+			t.Errorf("isValidORCIDChecksum(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// archive.go
+// Streams member files out of zip, tar and tar.gz archives without extracting them.
+package croissant
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// archiveKind identifies a supported archive format.
+type archiveKind int
+
+const (
+	notArchive archiveKind = iota
+	zipArchive
+	tarArchive
+	tarGzipArchive
+)
+
+// detectArchiveKind determines the archive format of a distribution from its
+// encoding format, falling back to the file extension.
+func detectArchiveKind(dist Distribution, localPath string) archiveKind {
+	switch dist.EncodingFormat {
+	case "application/zip", "application/x-zip-compressed":
+		return zipArchive
+	case "application/x-tar":
+		if hasTarGzipExtension(localPath) {
+			return tarGzipArchive
+		}
+		return tarArchive
+	case "application/gzip", "application/x-gzip", "application/x-gtar":
+		return tarGzipArchive
+	}
+
+	lower := strings.ToLower(localPath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return zipArchive
+	case hasTarGzipExtension(lower):
+		return tarGzipArchive
+	case strings.HasSuffix(lower, ".tar"):
+		return tarArchive
+	default:
+		return notArchive
+	}
+}
+
+func hasTarGzipExtension(localPath string) bool {
+	lower := strings.ToLower(localPath)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// walkArchive returns an iterator over the regular files of a local archive.
+// Entries can only be opened during iteration, before the next entry is requested.
+func walkArchive(ctx context.Context, archivePath string, kind archiveKind) iter.Seq2[FileEntry, error] {
+	switch kind {
+	case zipArchive:
+		return walkZipArchive(ctx, archivePath)
+	case tarArchive, tarGzipArchive:
+		return walkTarArchive(ctx, archivePath, kind == tarGzipArchive)
+	case notArchive:
+	}
+
+	return func(yield func(FileEntry, error) bool) {
+		yield(FileEntry{}, CroissantError{Message: "unsupported archive format", Value: archivePath})
+	}
+}
+
+// walkZipArchive iterates over the members of a zip archive.
+func walkZipArchive(ctx context.Context, archivePath string) iter.Seq2[FileEntry, error] {
+	return func(yield func(FileEntry, error) bool) {
+		reader, err := zip.OpenReader(filepath.Clean(archivePath))
+		if err != nil {
+			yield(FileEntry{}, CroissantError{Message: "failed to open zip archive", Value: err})
+			return
+		}
+		defer reader.Close()
+
+		for _, file := range reader.File {
+			if err := ctx.Err(); err != nil {
+				yield(FileEntry{}, err)
+				return
+			}
+			if !file.Mode().IsRegular() {
+				continue
+			}
+
+			entry := archiveFileEntry(archivePath, file.Name, int64(file.UncompressedSize64)) //nolint:gosec
+			entry.open = func() (io.ReadCloser, error) {
+				member, err := file.Open()
+				if err != nil {
+					return nil, CroissantError{Message: "failed to open zip member", Value: err}
+				}
+				return member, nil
+			}
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// walkTarArchive iterates over the members of a tar archive, optionally gzip-compressed.
+func walkTarArchive(ctx context.Context, archivePath string, gzipped bool) iter.Seq2[FileEntry, error] {
+	return func(yield func(FileEntry, error) bool) {
+		file, err := os.Open(filepath.Clean(archivePath))
+		if err != nil {
+			yield(FileEntry{}, CroissantError{Message: "failed to open tar archive", Value: err})
+			return
+		}
+		defer file.Close()
+
+		var stream io.Reader = file
+		if gzipped {
+			gzipReader, err := gzip.NewReader(file)
+			if err != nil {
+				yield(FileEntry{}, CroissantError{Message: "failed to open gzip stream", Value: err})
+				return
+			}
+			defer gzipReader.Close()
+			stream = gzipReader
+		}
+
+		reader := tar.NewReader(stream)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(FileEntry{}, err)
+				return
+			}
+
+			header, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(FileEntry{}, CroissantError{Message: "failed to read tar archive", Value: err})
+				return
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+
+			entry := archiveFileEntry(archivePath, header.Name, header.Size)
+			entry.open = func() (io.ReadCloser, error) {
+				return io.NopCloser(reader), nil
+			}
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// archiveFileEntry returns a FileEntry describing a member of an archive.
+func archiveFileEntry(archivePath string, memberName string, size int64) FileEntry {
+	memberPath := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(memberName)), "/")
+
+	return FileEntry{
+		Path:     memberPath,
+		FullPath: filepath.Join(archivePath, filepath.FromSlash(memberPath)),
+		Archive:  archivePath,
+		Size:     size,
+	}
+}
+
+// OpenDistribution opens the content of a FileObject for reading.
+// FileObjects contained in an archive (through containedIn) are streamed out of it;
+// their content URL is the path of the member inside the archive.
+// The caller must close the returned reader.
+func OpenDistribution(ctx context.Context, metadata Metadata, distributionID string, baseDir string) (io.ReadCloser, error) {
+	dist := findDistribution(metadata, distributionID)
+	if dist == nil {
+		return nil, CroissantError{Message: "distribution not found", Value: distributionID}
+	}
+
+	return openDistributionContent(ctx, metadata, *dist, baseDir)
+}
+
+// openDistributionContent opens a FileObject, following containedIn into its container.
+func openDistributionContent(ctx context.Context, metadata Metadata, dist Distribution, baseDir string) (io.ReadCloser, error) {
+	if dist.ContainedIn == nil || dist.ContainedIn.ID == "" {
+		localPath, err := resolveContentPath(dist, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.Open(localPath)
+		if err != nil {
+			return nil, CroissantError{Message: "failed to open file", Value: err}
+		}
+		return file, nil
+	}
+
+	resolver := &fileSetResolver{
+		ctx:      ctx,
+		metadata: metadata,
+		baseDir:  baseDir,
+		visiting: map[string]bool{dist.ID: true},
+	}
+	next, stop := iter.Pull2(func(yield func(FileEntry, error) bool) { resolver.walkFileObject(dist, yield) })
+	entry, err, ok := next()
+	if !ok {
+		stop()
+		return nil, CroissantError{Message: "file not found", Value: dist.ContentURL}
+	}
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	reader, err := entry.Open()
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	// Keep the container open until the member has been read.
+	return &memberReadCloser{ReadCloser: reader, stop: stop}, nil
+}
+
+// memberReadCloser closes an archive member together with the walk over its archive.
+type memberReadCloser struct {
+	io.ReadCloser
+	stop func()
+}
+
+func (m *memberReadCloser) Close() error {
+	err := m.ReadCloser.Close()
+	m.stop()
+	return err
+}
@@ -0,0 +1,166 @@
+// File: pkg/croissant/archive_test.go
+package croissant
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// archiveTestMembers are the files written to every test archive.
+var archiveTestMembers = map[string]string{ //nolint:gochecknoglobals
+	"train2014/a.jpg":   "image-a",
+	"train2014/b.jpg":   "image-b",
+	"annotations.csv":   "id,label\n1,cat\n2,dog\n",
+	"train2014/readme":  "not an image",
+	"val2014/sub/c.jpg": "image-c",
+}
+
+func writeTestZip(t *testing.T, archivePath string) {
+	t.Helper()
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for name, content := range archiveTestMembers {
+		member, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := member.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTarGz(t *testing.T, archivePath string) {
+	t.Helper()
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	writer := tar.NewWriter(gzipWriter)
+	for name, content := range archiveTestMembers {
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// archiveTestMetadata describes a FileSet of images and a CSV FileObject inside an archive.
+func archiveTestMetadata(archiveName string, encodingFormat string) Metadata {
+	return Metadata{
+		Type: "sc:Dataset",
+		Name: "archive",
+		Distributions: []Distribution{
+			{ID: "archive", Type: "cr:FileObject", Name: "archive", ContentURL: archiveName, EncodingFormat: encodingFormat},
+			{ID: "images", Type: "cr:FileSet", Name: "images", ContainedIn: &FileObjectRef{ID: "archive"}, EncodingFormat: "image/jpeg", Includes: "*.jpg"},
+			{ID: "annotations.csv", Type: "cr:FileObject", Name: "annotations.csv", ContainedIn: &FileObjectRef{ID: "archive"}, ContentURL: "annotations.csv", EncodingFormat: "text/csv"},
+		},
+		RecordSets: []RecordSet{
+			{
+				ID:   "annotations",
+				Type: "cr:RecordSet",
+				Name: "annotations",
+				Fields: []Field{
+					{
+						ID:       "annotations/label",
+						Type:     "cr:Field",
+						Name:     "label",
+						DataType: NewSingleDataType(VT_scText),
+						Source: FieldSource{
+							FileObject: FileObject{ID: "annotations.csv"},
+							Extract:    Extract{Column: "label"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestArchiveFileSets(t *testing.T) {
+	cases := []struct {
+		name           string
+		encodingFormat string
+		write          func(*testing.T, string)
+	}{
+		{"data.zip", "application/zip", writeTestZip},
+		{"data.tar.gz", "application/x-tar", writeTestTarGz},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			c.write(t, filepath.Join(dir, c.name))
+			metadata := archiveTestMetadata(c.name, c.encodingFormat)
+
+			contents := make(map[string]string)
+			for entry, err := range FileSetEntries(context.Background(), metadata, "images", dir) {
+				if err != nil {
+					t.Fatalf("FileSetEntries: %v", err)
+				}
+				reader, err := entry.Open()
+				if err != nil {
+					t.Fatalf("Open(%s): %v", entry.Path, err)
+				}
+				content, err := io.ReadAll(reader)
+				_ = reader.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				contents[entry.Path] = string(content)
+			}
+
+			want := map[string]string{
+				"train2014/a.jpg":   "image-a",
+				"train2014/b.jpg":   "image-b",
+				"val2014/sub/c.jpg": "image-c",
+			}
+			if len(contents) != len(want) {
+				t.Fatalf("got members %v, want %v", contents, want)
+			}
+			for name, content := range want {
+				if contents[name] != content {
+					t.Errorf("member %s = %q, want %q", name, contents[name], content)
+				}
+			}
+
+			var labels []string
+			for record, err := range RecordsWithOptions(context.Background(), metadata, "annotations", RecordOptions{BaseDir: dir}) {
+				if err != nil {
+					t.Fatalf("Records: %v", err)
+				}
+				labels = append(labels, record["annotations/label"].(string))
+			}
+			if !slices.Equal(labels, []string{"cat", "dog"}) {
+				t.Errorf("labels = %v, want [cat dog]", labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// builder.go
+// Builds metadata programmatically, keeping the IDs of its distributions, record sets and fields consistent.
+package croissant
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DatasetBuilder builds the Metadata of a dataset with a fluent API.
+//
+// Distributions, record sets and fields are given IDs derived from their names, made
+// unique within the dataset. Fields are extracted from the distribution of their record
+// set, and keys refer to fields by name. Mistakes, such as a field added before any
+// record set, are reported by Build, which also validates the metadata.
+//
+// Example:
+//
+//	metadata, err := croissant.NewDataset("cities").
+//		SetDescription("Largest cities of the world.").
+//		AddFileObject("cities.csv", "data/cities.csv", "text/csv").
+//		AddRecordSet("cities").
+//		AddField("name", croissant.VT_scText).
+//		AddField("population", croissant.VT_scInt).
+//		SetKey("name").
+//		Build()
+type DatasetBuilder struct {
+	metadata Metadata
+	ids      map[string]bool
+	errs     []error
+	// Index of the record set fields are added to, or -1.
+	recordSet int
+	// ID of the distribution the fields of each record set are extracted from, by record set index.
+	sources []string
+}
+
+// NewDataset starts building the metadata of a dataset with the given name.
+func NewDataset(name string) *DatasetBuilder {
+	return &DatasetBuilder{
+		metadata: Metadata{
+			Context:    CreateDefaultContext(),
+			Type:       "sc:Dataset",
+			Name:       name,
+			ConformsTo: "http://mlcommons.org/croissant/1.0",
+		},
+		ids:       make(map[string]bool),
+		recordSet: -1,
+	}
+}
+
+// SetDescription sets the description of the dataset.
+func (b *DatasetBuilder) SetDescription(description string) *DatasetBuilder {
+	b.metadata.Description = description
+	return b
+}
+
+// SetVersion sets the version of the dataset, e.g. "1.0.0".
+func (b *DatasetBuilder) SetVersion(version string) *DatasetBuilder {
+	b.metadata.Version = version
+	return b
+}
+
+// SetLicense sets the license of the dataset, e.g. "https://spdx.org/licenses/CC-BY-4.0.html".
+func (b *DatasetBuilder) SetLicense(license string) *DatasetBuilder {
+	b.metadata.License = license
+	return b
+}
+
+// SetURL sets the url of the dataset's webpage.
+func (b *DatasetBuilder) SetURL(url string) *DatasetBuilder {
+	b.metadata.URL = url
+	return b
+}
+
+// SetDatePublished sets the date the dataset was published, e.g. "2024-06-01".
+func (b *DatasetBuilder) SetDatePublished(date string) *DatasetBuilder {
+	b.metadata.DatePublished = date
+	return b
+}
+
+// AddCreator adds creators of the dataset.
+func (b *DatasetBuilder) AddCreator(creators ...Agent) *DatasetBuilder {
+	b.metadata.Creator = append(b.metadata.Creator, creators...)
+	return b
+}
+
+// AddPublisher adds publishers of the dataset.
+func (b *DatasetBuilder) AddPublisher(publishers ...Agent) *DatasetBuilder {
+	b.metadata.Publisher = append(b.metadata.Publisher, publishers...)
+	return b
+}
+
+// AddKeywords adds free text keywords to the dataset.
+func (b *DatasetBuilder) AddKeywords(keywords ...string) *DatasetBuilder {
+	b.metadata.Keywords = append(b.metadata.Keywords, NewKeywords(keywords...)...)
+	return b
+}
+
+// AddFileObject adds a file to the dataset. Record sets added next extract their fields from it.
+func (b *DatasetBuilder) AddFileObject(name string, contentURL string, encodingFormat string) *DatasetBuilder {
+	return b.AddDistribution(Distribution{
+		Type:           "cr:FileObject",
+		Name:           name,
+		ContentURL:     contentURL,
+		EncodingFormat: encodingFormat,
+	})
+}
+
+// AddFileSet adds the files matching the glob pattern includes within the archive or directory
+// containedIn, the name or ID of a distribution added before. Record sets added next extract
+// their fields from them.
+func (b *DatasetBuilder) AddFileSet(name string, containedIn string, includes string, encodingFormat string) *DatasetBuilder {
+	container, exists := b.distributionID(containedIn)
+	if !exists {
+		b.errs = append(b.errs, CroissantError{Message: fmt.Sprintf("FileSet \"%s\" is contained in a distribution that does not exist", name), Value: containedIn})
+	}
+
+	return b.AddDistribution(Distribution{
+		Type:           "cr:FileSet",
+		Name:           name,
+		ContainedIn:    &FileObjectRef{ID: container},
+		Includes:       includes,
+		EncodingFormat: encodingFormat,
+	})
+}
+
+// AddDistribution adds a FileObject or FileSet, e.g. to set its checksums. Its ID is
+// generated from its name if empty, and its type defaults to "cr:FileObject".
+func (b *DatasetBuilder) AddDistribution(distribution Distribution) *DatasetBuilder {
+	if distribution.Type == "" {
+		distribution.Type = "cr:FileObject"
+	}
+	if distribution.ID == "" {
+		distribution.ID = b.uniqueID(distribution.Name)
+	} else {
+		b.reserveID(distribution.ID)
+	}
+	b.metadata.Distributions = append(b.metadata.Distributions, distribution)
+
+	return b
+}
+
+// AddRecordSet adds a record set whose fields are extracted from the distribution added last.
+// Fields and keys are added to the record set added last.
+func (b *DatasetBuilder) AddRecordSet(name string) *DatasetBuilder {
+	source := ""
+	if len(b.metadata.Distributions) > 0 {
+		source = b.metadata.Distributions[len(b.metadata.Distributions)-1].ID
+	}
+
+	return b.addRecordSet(name, source)
+}
+
+// AddRecordSetFrom adds a record set whose fields are extracted from the given distribution,
+// by name or ID. Fields and keys are added to the record set added last.
+func (b *DatasetBuilder) AddRecordSetFrom(name string, distribution string) *DatasetBuilder {
+	source, exists := b.distributionID(distribution)
+	if !exists {
+		b.errs = append(b.errs, CroissantError{Message: fmt.Sprintf("RecordSet \"%s\" is extracted from a distribution that does not exist", name), Value: distribution})
+	}
+
+	return b.addRecordSet(name, source)
+}
+
+// addRecordSet adds a record set extracted from the distribution with the given ID.
+func (b *DatasetBuilder) addRecordSet(name string, source string) *DatasetBuilder {
+	b.metadata.RecordSets = append(b.metadata.RecordSets, RecordSet{
+		ID:   b.uniqueID(cleanFieldName(name)),
+		Type: "cr:RecordSet",
+		Name: name,
+	})
+	b.recordSet = len(b.metadata.RecordSets) - 1
+	b.sources = append(b.sources, source)
+
+	return b
+}
+
+// AddField adds a field to the record set added last, extracted from the column with the same name.
+func (b *DatasetBuilder) AddField(name string, dataType string) *DatasetBuilder {
+	return b.AddCustomField(Field{
+		Name:     name,
+		DataType: NewSingleDataType(dataType),
+		Source:   FieldSource{Extract: Extract{Column: name}},
+	})
+}
+
+// AddCustomField adds a field to the record set added last, e.g. to extract it with a jsonPath
+// or to describe it. Its ID is generated from its name if empty, its type defaults to
+// "cr:Field", and its source refers to the record set's distribution unless set.
+func (b *DatasetBuilder) AddCustomField(field Field) *DatasetBuilder {
+	if b.recordSet < 0 {
+		b.errs = append(b.errs, CroissantError{Message: "field is added before any record set", Value: field.Name})
+		return b
+	}
+	recordSet := &b.metadata.RecordSets[b.recordSet]
+
+	if field.Type == "" {
+		field.Type = "cr:Field"
+	}
+	if field.ID == "" {
+		field.ID = b.uniqueID(recordSet.ID + "/" + cleanFieldName(field.Name))
+	} else {
+		b.reserveID(field.ID)
+	}
+	if field.Source.FileObject.ID == "" && field.Source.FileSet.ID == "" && len(field.SubField) == 0 {
+		b.setFieldSource(&field.Source, b.sources[b.recordSet])
+	}
+	recordSet.Fields = append(recordSet.Fields, field)
+
+	return b
+}
+
+// SetKey sets the key of the record set added last to the fields with the given names.
+// Several fields make a composite key.
+func (b *DatasetBuilder) SetKey(fieldNames ...string) *DatasetBuilder {
+	if b.recordSet < 0 {
+		b.errs = append(b.errs, CroissantError{Message: "key is set before any record set", Value: fieldNames})
+		return b
+	}
+	recordSet := &b.metadata.RecordSets[b.recordSet]
+
+	ids := make([]string, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		index := slices.IndexFunc(recordSet.Fields, func(field Field) bool {
+			return field.Name == fieldName || field.ID == fieldName
+		})
+		if index < 0 {
+			b.errs = append(b.errs, CroissantError{Message: fmt.Sprintf("key of RecordSet \"%s\" references a field that does not exist", recordSet.Name), Value: fieldName})
+			continue
+		}
+		ids = append(ids, recordSet.Fields[index].ID)
+	}
+	recordSet.Key = NewCompositeKey(ids...)
+
+	return b
+}
+
+// Build returns the metadata, validated with the default validation options.
+// The error lists the mistakes made while building, or the validation errors.
+func (b *DatasetBuilder) Build() (Metadata, error) {
+	return b.BuildWithOptions(DefaultValidationOptions())
+}
+
+// BuildWithOptions returns the metadata, validated with the given options.
+// The error lists the mistakes made while building, or the validation errors.
+func (b *DatasetBuilder) BuildWithOptions(options ValidationOptions) (Metadata, error) {
+	if err := errors.Join(b.errs...); err != nil {
+		return b.metadata, CroissantError{Message: "failed to build metadata", Value: err}
+	}
+
+	issues := ValidateMetadataWithOptions(b.metadata, options)
+	if issues.HasErrors() {
+		return b.metadata, CroissantError{Message: "validation failed", Value: issues.Report()}
+	}
+
+	return b.metadata, nil
+}
+
+// setFieldSource refers a field source to the distribution with the given ID.
+func (b *DatasetBuilder) setFieldSource(source *FieldSource, distributionID string) {
+	index := slices.IndexFunc(b.metadata.Distributions, func(dist Distribution) bool {
+		return dist.ID == distributionID
+	})
+	if index < 0 {
+		return
+	}
+	if b.metadata.Distributions[index].Type == "cr:FileSet" {
+		source.FileSet = FileObject{ID: distributionID}
+	} else {
+		source.FileObject = FileObject{ID: distributionID}
+	}
+}
+
+// distributionID returns the ID of the distribution with the given name or ID.
+func (b *DatasetBuilder) distributionID(nameOrID string) (string, bool) {
+	for _, dist := range b.metadata.Distributions {
+		if dist.ID == nameOrID || dist.Name == nameOrID {
+			return dist.ID, true
+		}
+	}
+
+	return "", false
+}
+
+// uniqueID returns id, followed by a number if id is already used in the dataset.
+func (b *DatasetBuilder) uniqueID(id string) string {
+	unique := id
+	for i := 2; b.ids[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", id, i)
+	}
+	b.ids[unique] = true
+
+	return unique
+}
+
+// reserveID records an ID given by the caller, reporting duplicates.
+func (b *DatasetBuilder) reserveID(id string) {
+	if b.ids[id] {
+		b.errs = append(b.errs, CroissantError{Message: "ID is used more than once", Value: id})
+	}
+	b.ids[id] = true
+}
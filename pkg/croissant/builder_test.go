@@ -0,0 +1,88 @@
+// File: pkg/croissant/builder_test.go
+package croissant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDatasetBuilder(t *testing.T) {
+	metadata, err := NewDataset("cities").
+		SetDescription("Largest cities of the world.").
+		AddCreator(NewOrganization("Example Lab")).
+		AddFileObject("cities.csv", "data/cities.csv", "text/csv").
+		AddRecordSet("cities").
+		AddField("name", VT_scText).
+		AddField("country code", VT_scText).
+		AddField("population", VT_scInt).
+		SetKey("name", "country code").
+		AddFileObject("photos.zip", "data/photos.zip", "application/zip").
+		AddFileSet("photos", "photos.zip", "*.jpg", "image/jpeg").
+		AddRecordSet("photos").
+		AddCustomField(Field{Name: "image", DataType: NewSingleDataType(VT_scImage), Source: FieldSource{Extract: Extract{FileProperty: "content"}}}).
+		AddRecordSetFrom("cities", "cities.csv").
+		AddField("name", VT_scText).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := []string{metadata.Distributions[0].ID, metadata.Distributions[1].ID, metadata.Distributions[2].ID}; !reflect.DeepEqual(ids, []string{"cities.csv", "photos.zip", "photos"}) {
+		t.Errorf("distribution IDs = %v", ids)
+	}
+	if metadata.Distributions[2].ContainedIn.ID != "photos.zip" {
+		t.Errorf("FileSet containedIn = %+v", metadata.Distributions[2].ContainedIn)
+	}
+
+	cities := metadata.RecordSets[0]
+	var fieldIDs []string
+	for _, field := range cities.Fields {
+		fieldIDs = append(fieldIDs, field.ID)
+		if field.Source.FileObject.ID != "cities.csv" || field.Source.Extract.Column != field.Name {
+			t.Errorf("field %s source = %+v", field.ID, field.Source)
+		}
+	}
+	if !reflect.DeepEqual(fieldIDs, []string{"cities/name", "cities/country_code", "cities/population"}) {
+		t.Errorf("field IDs = %v", fieldIDs)
+	}
+	if !reflect.DeepEqual(*cities.Key, RecordSetKey{{ID: "cities/name"}, {ID: "cities/country_code"}}) {
+		t.Errorf("key = %+v", *cities.Key)
+	}
+
+	if image := metadata.RecordSets[1].Fields[0]; image.ID != "photos_2/image" || image.Source.FileSet.ID != "photos" {
+		t.Errorf("image field = %+v", image)
+	}
+	if second := metadata.RecordSets[2]; second.ID != "cities_2" || second.Fields[0].ID != "cities_2/name" {
+		t.Errorf("second cities record set = %+v", second)
+	}
+}
+
+func TestDatasetBuilderErrors(t *testing.T) {
+	_, err := NewDataset("cities").
+		AddField("orphan", VT_scText).
+		AddFileObject("cities.csv", "data/cities.csv", "text/csv").
+		AddFileSet("photos", "photos.zip", "*.jpg", "image/jpeg").
+		AddRecordSetFrom("cities", "missing.csv").
+		AddField("name", VT_scText).
+		SetKey("id").
+		Build()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		"field is added before any record set: orphan",
+		`FileSet "photos" is contained in a distribution that does not exist: photos.zip`,
+		`RecordSet "cities" is extracted from a distribution that does not exist: missing.csv`,
+		`key of RecordSet "cities" references a field that does not exist: id`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q: %v", want, err)
+		}
+	}
+
+	_, err = NewDataset("").AddFileObject("cities.csv", "data/cities.csv", "text/csv").Build()
+	if err == nil || !strings.Contains(err.Error(), "validation failed") {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+// checksum.go
+// Verifies the checksums and sizes of distributions against their files.
+package croissant
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // MD5 is part of the Croissant specification, not used for security.
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FileChecksums holds the size and checksums of a file's content.
+type FileChecksums struct {
+	Size   int64
+	SHA256 string
+	MD5    string
+}
+
+// ComputeChecksums reads content to its end and returns its size, SHA-256 and MD5 checksums.
+func ComputeChecksums(content io.Reader) (FileChecksums, error) {
+	sha256Hash := sha256.New()
+	md5Hash := md5.New() //nolint:gosec
+	size, err := io.Copy(io.MultiWriter(sha256Hash, md5Hash), content)
+	if err != nil {
+		return FileChecksums{}, CroissantError{Message: "failed to calculate hash", Value: err}
+	}
+
+	return FileChecksums{
+		Size:   size,
+		SHA256: hex.EncodeToString(sha256Hash.Sum(nil)),
+		MD5:    hex.EncodeToString(md5Hash.Sum(nil)),
+	}, nil
+}
+
+// contentSizeUnits maps contentSize units to their size in bytes.
+var contentSizeUnits = map[string]int64{ //nolint:gochecknoglobals
+	"":      1,
+	"B":     1,
+	"BYTES": 1,
+	"KB":    1000,
+	"MB":    1000 * 1000,
+	"GB":    1000 * 1000 * 1000,
+	"TB":    1000 * 1000 * 1000 * 1000,
+	"KIB":   1 << 10,
+	"MIB":   1 << 20,
+	"GIB":   1 << 30,
+	"TIB":   1 << 40,
+}
+
+// binaryUnitSizes maps the size of decimal units to the size of the binary unit of the same name.
+var binaryUnitSizes = map[int64]int64{ //nolint:gochecknoglobals
+	1000:                      1 << 10,
+	1000 * 1000:               1 << 20,
+	1000 * 1000 * 1000:        1 << 30,
+	1000 * 1000 * 1000 * 1000: 1 << 40,
+}
+
+// ParseContentSize parses a contentSize such as "117743 B", "1.5 MB" or "1024"
+// (bytes when no unit is given) into its number and unit size in bytes.
+func ParseContentSize(contentSize string) (float64, int64, error) {
+	text := strings.TrimSpace(contentSize)
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(text)
+	}
+
+	number, err := strconv.ParseFloat(text[:end], 64)
+	unitSize, known := contentSizeUnits[strings.ToUpper(strings.TrimSpace(text[end:]))]
+	if err != nil || !known || number < 0 {
+		return 0, 0, CroissantError{Message: "invalid content size", Value: contentSize}
+	}
+
+	return number, unitSize, nil
+}
+
+// ContentSizeMatches checks if a file size matches a contentSize.
+//
+// Sizes in bytes must match exactly. Sizes in larger units match if the file size,
+// expressed in that unit, rounds to the declared number at its precision
+// (e.g. 1 523 000 bytes match "1.5 MB"). As KB, MB and GB are commonly used for
+// binary sizes, both 1000 and 1024 based units are accepted for them.
+func ContentSizeMatches(contentSize string, size int64) (bool, error) {
+	number, unitSize, err := ParseContentSize(contentSize)
+	if err != nil {
+		return false, err
+	}
+	if unitSize == 1 {
+		return number == float64(size), nil
+	}
+
+	text := strings.TrimSpace(contentSize)
+	decimals := 0
+	if dot := strings.IndexByte(text, '.'); dot >= 0 {
+		decimals = strings.IndexFunc(text[dot+1:], func(r rune) bool { return r < '0' || r > '9' })
+		if decimals < 0 {
+			decimals = len(text) - dot - 1
+		}
+	}
+
+	unitSizes := []int64{unitSize}
+	if binarySize, exists := binaryUnitSizes[unitSize]; exists {
+		unitSizes = append(unitSizes, binarySize)
+	}
+	scale := math.Pow(10, float64(decimals))
+	for _, candidate := range unitSizes {
+		if math.Round(float64(size)/float64(candidate)*scale) == math.Round(number*scale) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// VerifyFileObject reads the content of a FileObject, including a file contained in an
+// archive, and compares it with its declared sha256, md5 and contentSize.
+// Returns a description of each mismatch, and the checksums of the content.
+func VerifyFileObject(ctx context.Context, metadata Metadata, dist Distribution, baseDir string) ([]string, FileChecksums, error) {
+	content, err := openDistributionContent(ctx, metadata, dist, baseDir)
+	if err != nil {
+		return nil, FileChecksums{}, err
+	}
+	defer content.Close()
+
+	checksums, err := ComputeChecksums(content)
+	if err != nil {
+		return nil, FileChecksums{}, err
+	}
+
+	var mismatches []string
+	if dist.SHA256 != "" && !strings.EqualFold(dist.SHA256, checksums.SHA256) {
+		mismatches = append(mismatches, fmt.Sprintf("SHA256 hash \"%s\" does not match the file, whose hash is \"%s\".", dist.SHA256, checksums.SHA256))
+	}
+	if dist.MD5 != "" && !strings.EqualFold(dist.MD5, checksums.MD5) {
+		mismatches = append(mismatches, fmt.Sprintf("MD5 hash \"%s\" does not match the file, whose hash is \"%s\".", dist.MD5, checksums.MD5))
+	}
+	if dist.ContentSize != "" {
+		matches, err := ContentSizeMatches(dist.ContentSize, checksums.Size)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("ContentSize \"%s\" is not a valid size.", dist.ContentSize))
+		} else if !matches {
+			mismatches = append(mismatches, fmt.Sprintf("ContentSize \"%s\" does not match the file, whose size is %d B.", dist.ContentSize, checksums.Size))
+		}
+	}
+
+	return mismatches, checksums, nil
+}
@@ -0,0 +1,144 @@
+// File: pkg/croissant/checksum_test.go
+package croissant
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestContentSizeMatches(t *testing.T) {
+	cases := []struct {
+		contentSize string
+		size        int64
+		want        bool
+	}{
+		{"117743 B", 117743, true},
+		{"117743 B", 117744, false},
+		{"1024", 1024, true},
+		{"2 KB", 2000, true},
+		{"2 kb", 2048, true},
+		{"2KB", 3000, false},
+		{"1.5 MB", 1523000, true},
+		{"1.5 MB", 1700000, false},
+		{"1.5 MiB", 1572864, true},
+		{"3 GB", 3 << 30, true},
+	}
+	for _, c := range cases {
+		got, err := ContentSizeMatches(c.contentSize, c.size)
+		if err != nil {
+			t.Errorf("ContentSizeMatches(%q, %d): %v", c.contentSize, c.size, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ContentSizeMatches(%q, %d) = %v, want %v", c.contentSize, c.size, got, c.want)
+		}
+	}
+
+	for _, invalid := range []string{" B", "12 parsecs", "-1 B", ""} {
+		if _, err := ContentSizeMatches(invalid, 0); err == nil {
+			t.Errorf("ContentSizeMatches(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestValidateVerifyChecksums(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id\n1\n")
+
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt))
+	metadata.Distributions[0].SHA256 = "d7ba0bb34ea0d4cdf5e7f5cbd8c64d8a8ba0c8fa5b5ed17d1ce9f6b4f7d58ef0"
+	metadata.Distributions[0].MD5 = "4d39b4f3d4c5a6d4c3e6f0a3c2d1b0a9"
+	metadata.Distributions[0].ContentSize = "5 B"
+
+	options := DefaultValidationOptions()
+	options.StrictMode = false
+	options.BaseDir = dir
+	if issues := ValidateMetadataWithOptions(metadata, options); issues.HasErrors() {
+		t.Fatalf("metadata should be valid without VerifyChecksums: %s", issues.Report())
+	}
+
+	options.VerifyChecksums = true
+	report := ValidateMetadataWithOptions(metadata, options).Report()
+	for _, want := range []string{
+		`SHA256 hash "d7ba0bb34ea0d4cdf5e7f5cbd8c64d8a8ba0c8fa5b5ed17d1ce9f6b4f7d58ef0" does not match the file, whose hash is "`,
+		`MD5 hash "4d39b4f3d4c5a6d4c3e6f0a3c2d1b0a9" does not match the file, whose hash is "`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "ContentSize") {
+		t.Errorf("matching contentSize should not be reported:\n%s", report)
+	}
+
+	checksums, err := ComputeChecksums(strings.NewReader("id\n1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata.Distributions[0].SHA256 = strings.ToUpper(checksums.SHA256)
+	metadata.Distributions[0].MD5 = checksums.MD5
+	metadata.Distributions[0].ContentSize = "6 B"
+	report = ValidateMetadataWithOptions(metadata, options).Report()
+	if want := `ContentSize "6 B" does not match the file, whose size is 5 B.`; !strings.Contains(report, want) || strings.Contains(report, "hash") {
+		t.Errorf("report should only contain %q:\n%s", want, report)
+	}
+}
+
+func TestVerifyDistributions(t *testing.T) {
+	dir := t.TempDir()
+	writeTestZip(t, filepath.Join(dir, "data.zip"))
+	metadata := archiveTestMetadata("data.zip", "application/zip")
+	metadata.Distributions[2].SHA256 = "0000"
+	metadata.Distributions = append(metadata.Distributions,
+		Distribution{ID: "remote", Type: "cr:FileObject", Name: "remote", ContentURL: "https://example.com/data.csv"})
+
+	var progress []int
+	options := DefaultVerifyOptions()
+	options.BaseDir = dir
+	options.Workers = 4
+	options.Progress = func(verified int, total int) {
+		if total != 6 {
+			t.Errorf("Progress total = %d, want 6", total)
+		}
+		progress = append(progress, verified)
+	}
+	results, err := VerifyDistributions(context.Background(), metadata, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, result := range results {
+		got = append(got, fmt.Sprintf("%s %s ok=%v skipped=%v", result.Distribution, filepath.Base(result.Path), result.OK(), result.Skipped))
+	}
+	want := []string{
+		"archive data.zip ok=true skipped=false",
+		"images a.jpg ok=true skipped=false",
+		"images b.jpg ok=true skipped=false",
+		"images c.jpg ok=true skipped=false",
+		"annotations.csv annotations.csv ok=false skipped=false",
+		"remote data.csv ok=true skipped=true",
+	}
+	if len(got) == len(want) {
+		// Archive members are listed in archive order.
+		slices.Sort(got[1:4])
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VerifyDistributions = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(progress, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Progress calls = %v, want 1 to 6", progress)
+	}
+
+	checksums, _ := ComputeChecksums(strings.NewReader(archiveTestMembers["val2014/sub/c.jpg"]))
+	for _, result := range results {
+		if filepath.Base(result.Path) == "c.jpg" && result.Checksums != checksums {
+			t.Errorf("checksums of c.jpg = %+v, want %+v", result.Checksums, checksums)
+		}
+	}
+}
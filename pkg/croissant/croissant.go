@@ -8,7 +8,7 @@
 //
 // # Basic Usage
 //
-// Generate metadata from a CSV file:
+// Generate metadata from a CSV or Parquet file:
 //
 //	outputPath, err := croissant.GenerateMetadata("data.csv", "dataset.jsonld")
 //	if err != nil {
@@ -108,6 +108,8 @@
 //		CheckDataTypes:  true,  // Validate data type specifications
 //		ValidateURLs:    false, // Skip network calls for URL validation
 //		CheckFileExists: true,  // Verify referenced files exist
+//		VerifyChecksums: true,  // Verify checksums and sizes of local files
+//		CheckData:       true,  // Verify data values match field types
 //	}
 //
 //	issues, err := croissant.ValidateJSONWithOptions(data, options)
@@ -238,7 +240,7 @@ func CreateDefaultContext() Context {
 	}
 }
 
-// GenerateMetadata generates Croissant metadata from a CSV file (simple API).
+// GenerateMetadata generates Croissant metadata from a CSV or Parquet file (simple API).
 func GenerateMetadata(csvPath string, outputPath string) (string, error) {
 	metadata, err := GenerateMetadataWithValidation(csvPath, outputPath)
 	if err != nil {
@@ -253,7 +255,8 @@ func GenerateMetadata(csvPath string, outputPath string) (string, error) {
 	return outputPath, nil
 }
 
-// GenerateMetadataWithValidation generates Croissant metadata with validation from a CSV file.
+// GenerateMetadataWithValidation generates Croissant metadata with validation from a CSV or Parquet file.
+// Parquet files are recognized by their ".parquet" extension.
 func GenerateMetadataWithValidation(csvPath string, outputPath string) (*MetadataWithValidation, error) {
 	// Get file information
 	fileName := filepath.Base(csvPath)
@@ -269,40 +272,17 @@ func GenerateMetadataWithValidation(csvPath string, outputPath string) (*Metadat
 		return nil, CroissantError{Message: "failed to calculate SHA-256", Value: err}
 	}
 
-	// Get column information
-	headers, firstRow, err := GetCSVColumns(csvPath)
-	if err != nil {
-		return nil, CroissantError{Message: "failed to read CSV", Value: err}
+	// Create fields based on the file columns with data type inference
+	var fields []Field
+	encodingFormat := "text/csv"
+	if IsParquetFile(csvPath) {
+		encodingFormat = parquetEncodingFormat
+		fields, err = generateParquetFields(csvPath, fileName)
+	} else {
+		fields, err = generateCSVFields(csvPath, fileName)
 	}
-
-	// Create fields based on CSV columns with data type inference
-	fields := make([]Field, 0, len(headers))
-	for i, header := range headers {
-		fieldID := fmt.Sprintf("main/%s", cleanFieldName(header))
-		dataType := VT_scText // Default
-
-		// Infer data type from first row if available
-		if firstRow != nil && i < len(firstRow) {
-			dataType = InferDataType(firstRow[i])
-		}
-
-		field := Field{
-			ID:          fieldID,
-			Type:        "cr:Field",
-			Name:        header,
-			Description: fmt.Sprintf("Field for %s", header),
-			DataType:    NewSingleDataType(dataType),
-			Source: FieldSource{
-				Extract: Extract{
-					Column: header,
-				},
-				FileObject: FileObject{
-					ID: fileName,
-				},
-			},
-		}
-
-		fields = append(fields, field)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create metadata structure
@@ -322,7 +302,7 @@ func GenerateMetadataWithValidation(csvPath string, outputPath string) (*Metadat
 				Name:           fileName,
 				ContentSize:    fmt.Sprintf("%d B", fileSize),
 				ContentURL:     fileName,
-				EncodingFormat: "text/csv",
+				EncodingFormat: encodingFormat,
 				SHA256:         fileSHA256,
 			},
 		},
@@ -371,6 +351,59 @@ func GenerateMetadataWithValidation(csvPath string, outputPath string) (*Metadat
 	return metadataWithValidation, nil
 }
 
+// generateCSVFields creates a field for each column of a CSV file,
+// inferring data types from all of its rows.
+func generateCSVFields(csvPath string, fileName string) ([]Field, error) {
+	profiles, err := ProfileCSV(csvPath)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to read CSV", Value: err}
+	}
+
+	fields := make([]Field, 0, len(profiles))
+	for _, profile := range profiles {
+		fields = append(fields, generateField(profile.Name, profile.DataType, fileName))
+	}
+
+	return fields, nil
+}
+
+// generateParquetFields creates a field for each column of a Parquet file,
+// mapping data types from the Parquet schema.
+func generateParquetFields(parquetPath string, fileName string) ([]Field, error) {
+	columns, err := GetParquetColumns(parquetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]Field, 0, len(columns))
+	for _, column := range columns {
+		field := generateField(column.Name, column.DataType, fileName)
+		field.Repeated = column.Repeated
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// generateField creates a field of the main record set extracted from a column.
+func generateField(column string, dataType string, fileName string) Field {
+	return Field{
+		ID:          fmt.Sprintf("main/%s", cleanFieldName(column)),
+		Type:        "cr:Field",
+		Name:        column,
+		Description: fmt.Sprintf("Field for %s", column),
+		DataType:    NewSingleDataType(dataType),
+		Source: FieldSource{
+			Extract: Extract{
+				Column: column,
+			},
+			FileObject: FileObject{
+				ID: fileName,
+			},
+		},
+	}
+}
+
 // cleanFieldName cleans field names to be valid identifiers.
 func cleanFieldName(name string) string {
 	// Replace spaces and special characters with underscores
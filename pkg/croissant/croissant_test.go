@@ -14,11 +14,14 @@ func TestInferDataType(t *testing.T) {
 		{"true", "sc:Boolean"},
 		{"false", "sc:Boolean"},
 		{"123", "sc:Integer"},
+		{"1", "sc:Integer"},
 		{"-456", "sc:Integer"},
 		{"3.14", "sc:Number"},
 		{"2.5e10", "sc:Number"},
 		{"2023-01-01", "sc:DateTime"},
 		{"01/15/2023", "sc:DateTime"},
+		{"2023-01-01T10:00:00Z", "sc:DateTime"},
+		{"2023-01-01 10:00:00", "sc:Text"},
 		{"https://example.com", "sc:URL"},
 		{"http://foo.org", "sc:URL"},
 		{"test@example.com", "sc:Text"},
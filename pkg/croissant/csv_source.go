@@ -0,0 +1,97 @@
+// csv_source.go
+// Reads record values from CSV and TSV distributions.
+package croissant
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvSource yields the columns referenced by a set of fields, one row at a time.
+type csvSource struct {
+	file    io.ReadCloser
+	reader  *csv.Reader
+	columns map[string]int // field key -> column index
+}
+
+// openCSVSource opens a CSV distribution and maps each field to its column.
+func openCSVSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions, delimiter rune) (*csvSource, error) {
+	file, err := openDistributionContent(ctx, metadata, dist, options.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(file)
+	reader.Comma = delimiter
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if err != nil {
+		_ = file.Close()
+		return nil, CroissantError{Message: "failed to read CSV headers", Value: err}
+	}
+
+	headerIndex := make(map[string]int, len(headers))
+	for i, header := range headers {
+		headerIndex[strings.TrimSpace(header)] = i
+	}
+
+	columns := make(map[string]int, len(fields))
+	for _, field := range fields {
+		column := field.Source.Extract.Column
+		if column == "" {
+			_ = file.Close()
+			return nil, CroissantError{
+				Message: fmt.Sprintf("field %q must extract a column from CSV distribution %q", FieldKey(field), dist.ID),
+			}
+		}
+		index, exists := headerIndex[column]
+		if !exists {
+			_ = file.Close()
+			return nil, CroissantError{
+				Message: fmt.Sprintf("column not found in distribution %q", dist.ID),
+				Value:   column,
+			}
+		}
+		columns[FieldKey(field)] = index
+	}
+
+	return &csvSource{
+		file:    file,
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+// Next returns the values of the next CSV row.
+func (s *csvSource) Next() (map[string]interface{}, error) {
+	row, err := s.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, CroissantError{Message: "failed to read CSV row", Value: err}
+	}
+
+	values := make(map[string]interface{}, len(s.columns))
+	for key, index := range s.columns {
+		if index < len(row) {
+			values[key] = row[index]
+		} else {
+			values[key] = nil
+		}
+	}
+
+	return values, nil
+}
+
+// Close closes the underlying file.
+func (s *csvSource) Close() error {
+	return s.file.Close()
+}
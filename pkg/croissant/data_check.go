@@ -0,0 +1,296 @@
+// data_check.go
+// Checks that the data of record sets conforms to the declared field types.
+package croissant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxDataIssueSamples is the number of bad values quoted for each field.
+const maxDataIssueSamples = 5
+
+// fieldDataIssues accumulates the values of a field that could not be read.
+type fieldDataIssues struct {
+	count   int
+	samples []*RecordError
+}
+
+// checkRecordSetData reads every record of a record set and reports, for each field,
+// how many values could not be converted to its dataType, with a sample of them,
+// and whether its key is non-null and unique.
+// Record sets whose data cannot be read at all are reported as a warning.
+func checkRecordSetData(node *MetadataNode, rs *RecordSetNode, issues *Issues, options ValidationOptions) {
+	recordOptions := DefaultRecordOptions()
+	recordOptions.BaseDir = options.BaseDir
+	recordOptions.noJoins = true
+
+	reader, err := newRecordReader(context.Background(), node.metadata, recordSetNodeKey(rs), recordOptions)
+	if err != nil {
+		issues.AddWarning(fmt.Sprintf("Data of RecordSet \"%s\" could not be checked: %v", rs.Name, err), rs)
+		return
+	}
+	defer reader.Close()
+
+	keys := newKeyCheck(rs)
+	references := newReferenceChecks(node, rs, issues, recordOptions)
+	var fieldKeys []string
+	byField := make(map[string]*fieldDataIssues)
+	for {
+		record, recordErrs, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			issues.AddWarning(fmt.Sprintf("Data of RecordSet \"%s\" could not be checked after row %d: %v", rs.Name, reader.row, err), rs)
+			break
+		}
+		if keys != nil {
+			keys.add(record, reader.row)
+		}
+		for _, reference := range references {
+			reference.add(record, recordSetNodeKey(rs), reader.row)
+		}
+
+		for _, recordErr := range recordErrs {
+			fieldIssues, exists := byField[recordErr.Field]
+			if !exists {
+				fieldIssues = &fieldDataIssues{}
+				byField[recordErr.Field] = fieldIssues
+				fieldKeys = append(fieldKeys, recordErr.Field)
+			}
+			fieldIssues.count++
+			if len(fieldIssues.samples) < maxDataIssueSamples {
+				fieldIssues.samples = append(fieldIssues.samples, recordErr)
+			}
+		}
+	}
+
+	if keys != nil {
+		keys.report(rs, issues)
+	}
+	for _, reference := range references {
+		reference.report(rs, issues)
+	}
+
+	for _, key := range fieldKeys {
+		fieldIssues := byField[key]
+		field := findFieldNode(rs.Fields, key)
+		if field == nil {
+			issues.AddError(formatFieldDataIssues(key, "", fieldIssues), rs)
+			continue
+		}
+		dataType, _ := resolveValueType(field.DataType)
+		issues.AddError(formatFieldDataIssues(field.Name, dataType, fieldIssues), field)
+	}
+}
+
+// keyCheck tracks the key values of the records of a record set, to find null and duplicate keys.
+type keyCheck struct {
+	fields []string
+	// Row where each key value was first seen, by joinKey.
+	rows           map[string]int
+	nullCount      int
+	nullRows       []int
+	duplicateCount int
+	duplicates     []string
+}
+
+// newKeyCheck returns a check of a record set's single or composite key,
+// or nil if the record set has no key or its key references non-existent fields.
+func newKeyCheck(rs *RecordSetNode) *keyCheck {
+	if rs.Key == nil || len(rs.Key.GetKeyIDs()) == 0 {
+		return nil
+	}
+
+	check := &keyCheck{rows: make(map[string]int)}
+	for _, keyID := range rs.Key.GetKeyIDs() {
+		field := keyFieldNode(rs, keyID)
+		if field == nil {
+			// Already reported by validateRecordSetKey.
+			return nil
+		}
+		check.fields = append(check.fields, fieldNodeKey(field))
+	}
+
+	return check
+}
+
+// add records the key of a row. Empty text counts as null, like an empty CSV cell.
+func (c *keyCheck) add(record Record, row int) {
+	key, ok := joinKey(record, c.fields)
+	for _, field := range c.fields {
+		ok = ok && record[field] != ""
+	}
+	if !ok {
+		c.nullCount++
+		if len(c.nullRows) < maxDataIssueSamples {
+			c.nullRows = append(c.nullRows, row)
+		}
+		return
+	}
+
+	firstRow, exists := c.rows[key]
+	if !exists {
+		c.rows[key] = row
+		return
+	}
+	c.duplicateCount++
+	if len(c.duplicates) < maxDataIssueSamples {
+		c.duplicates = append(c.duplicates, fmt.Sprintf("%s in rows %d and %d", c.format(record), firstRow, row))
+	}
+}
+
+// format returns the key value of a record, as a tuple for composite keys.
+func (c *keyCheck) format(record Record) string {
+	values := make([]string, len(c.fields))
+	for i, field := range c.fields {
+		values[i] = fmt.Sprintf("%q", stringifyValue(record[field]))
+	}
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	return "(" + strings.Join(values, ", ") + ")"
+}
+
+// report adds an error for null and duplicate keys.
+func (c *keyCheck) report(rs *RecordSetNode, issues *Issues) {
+	if c.nullCount > 0 {
+		rows := make([]string, len(c.nullRows))
+		for i, row := range c.nullRows {
+			rows[i] = fmt.Sprint(row)
+		}
+		issues.AddError(fmt.Sprintf("Key of RecordSet \"%s\" is null in %d row(s), e.g. rows %s", rs.Name, c.nullCount, strings.Join(rows, ", ")), rs)
+	}
+	if c.duplicateCount > 0 {
+		issues.AddError(fmt.Sprintf("Key of RecordSet \"%s\" is not unique: %d duplicate row(s), e.g. %s", rs.Name, c.duplicateCount, strings.Join(c.duplicates, ", ")), rs)
+	}
+}
+
+// referenceCheck tracks the foreign keys of a record set that match no referenced record.
+type referenceCheck struct {
+	join   *recordJoin
+	issues fieldDataIssues
+}
+
+// newReferenceChecks loads the record sets referenced by the fields of a record set.
+// Referenced record sets that cannot be read are reported as a warning and not checked;
+// references to non-existent fields are reported by ValidateCrossReferences.
+func newReferenceChecks(node *MetadataNode, rs *RecordSetNode, issues *Issues, options RecordOptions) []*referenceCheck {
+	recordSet := FindRecordSet(node.metadata, recordSetNodeKey(rs))
+	if recordSet == nil {
+		return nil
+	}
+	visiting := map[string]bool{recordSetKey(*recordSet): true}
+	joins, err := newRecordJoins(node.metadata, recordSet, visiting)
+	if err != nil {
+		return nil
+	}
+
+	var checks []*referenceCheck
+	for _, join := range joins {
+		if err := join.load(context.Background(), node.metadata, options, visiting); err != nil {
+			issues.AddWarning(fmt.Sprintf("References of RecordSet \"%s\" to RecordSet \"%s\" could not be checked: %v", rs.Name, join.recordSet, err), rs)
+			continue
+		}
+		checks = append(checks, &referenceCheck{join: join})
+	}
+
+	return checks
+}
+
+// add checks the foreign key of a row.
+func (c *referenceCheck) add(record Record, recordSet string, row int) {
+	if recordErr := c.join.apply(record, recordSet, row); recordErr != nil {
+		c.issues.count++
+		if len(c.issues.samples) < maxDataIssueSamples {
+			c.issues.samples = append(c.issues.samples, recordErr)
+		}
+	}
+}
+
+// report adds an error for foreign keys without a matching referenced record.
+func (c *referenceCheck) report(rs *RecordSetNode, issues *Issues) {
+	if c.issues.count == 0 {
+		return
+	}
+
+	samples := make([]string, len(c.issues.samples))
+	for i, sample := range c.issues.samples {
+		samples[i] = fmt.Sprintf("row %d: %q", sample.Row, stringifyValue(sample.Value))
+	}
+	name := strings.Join(c.join.fields, ", ")
+	var node Node = rs
+	if field := findFieldNode(rs.Fields, c.join.fields[0]); field != nil && len(c.join.fields) == 1 {
+		name = field.Name
+		node = field
+	}
+	issues.AddError(fmt.Sprintf("Field \"%s\" has %d value(s) without a matching record in RecordSet \"%s\", e.g. %s",
+		name, c.issues.count, c.join.recordSet, strings.Join(samples, ", ")), node)
+}
+
+// fieldNodeKey returns the key under which a field node's value is stored in a Record.
+func fieldNodeKey(field *FieldNode) string {
+	if field.ID != "" {
+		return field.ID
+	}
+
+	return field.Name
+}
+
+// formatFieldDataIssues describes the bad values of a field, e.g.
+// `Field "age" has 2 value(s) that cannot be read as sc:Integer, e.g. row 3: "abc", row 7: "x" (value is not an integer)`.
+func formatFieldDataIssues(name string, dataType string, fieldIssues *fieldDataIssues) string {
+	samples := make([]string, len(fieldIssues.samples))
+	for i, sample := range fieldIssues.samples {
+		samples[i] = fmt.Sprintf("row %d: %q", sample.Row, stringifyValue(sample.Value))
+	}
+
+	readAs := ""
+	if dataType != "" {
+		readAs = " as " + dataType
+	}
+
+	return fmt.Sprintf("Field \"%s\" has %d value(s) that cannot be read%s, e.g. %s (%v)",
+		name, fieldIssues.count, readAs, strings.Join(samples, ", "), recordErrorCause(fieldIssues.samples[0]))
+}
+
+// recordErrorCause returns the message of the error underlying a RecordError.
+func recordErrorCause(recordErr *RecordError) string {
+	var croissantErr CroissantError
+	if errors.As(recordErr.Err, &croissantErr) {
+		return croissantErr.Message
+	}
+
+	return recordErr.Err.Error()
+}
+
+// findFieldNode looks up a field, or a subfield, by its record key.
+func findFieldNode(fields []*FieldNode, key string) *FieldNode {
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+		if field.ID == key || (field.ID == "" && field.Name == key) {
+			return field
+		}
+		if subField := findFieldNode(field.SubField, key); subField != nil {
+			return subField
+		}
+	}
+
+	return nil
+}
+
+// recordSetNodeKey returns the identifier used to refer to a record set node.
+func recordSetNodeKey(rs *RecordSetNode) string {
+	if rs.ID != "" {
+		return rs.ID
+	}
+
+	return rs.Name
+}
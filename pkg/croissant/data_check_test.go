@@ -0,0 +1,89 @@
+// File: pkg/croissant/data_check_test.go
+package croissant
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCheckData(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id,score\n1,0.5\nx,0.25\n3,high\n4.5,\n")
+
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt), csvTestField("score", VT_scFloat))
+	options := DefaultValidationOptions()
+	options.StrictMode = false
+	options.BaseDir = dir
+
+	if issues := ValidateMetadataWithOptions(metadata, options); issues.HasErrors() {
+		t.Fatalf("metadata should be valid without CheckData: %s", issues.Report())
+	}
+
+	options.CheckData = true
+	report := ValidateMetadataWithOptions(metadata, options).Report()
+	for _, want := range []string{
+		`[Metadata(test) > RecordSet(main) > Field(id)] Field "id" has 2 value(s) that cannot be read as sc:Integer, e.g. row 2: "x", row 4: "4.5" (value is not an integer)`,
+		`[Metadata(test) > RecordSet(main) > Field(score)] Field "score" has 1 value(s) that cannot be read as sc:Float, e.g. row 3: "high" (value is not a number)`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+
+	options.BaseDir = t.TempDir()
+	issues := ValidateMetadataWithOptions(metadata, options)
+	if issues.HasErrors() || !strings.Contains(issues.Report(), `Data of RecordSet "main" could not be checked`) {
+		t.Errorf("missing data should be reported as a warning:\n%s", issues.Report())
+	}
+}
+
+func TestValidateCheckDataKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "city,year,population\nParis,2020,2.1\nLyon,2020,0.5\nParis,2021,2.1\n,2021,0.1\nParis,2020,2.2\n")
+
+	metadata := csvTestMetadata(
+		csvTestField("city", VT_scText),
+		csvTestField("year", VT_scInt),
+		csvTestField("population", VT_scFloat),
+	)
+	options := DefaultValidationOptions()
+	options.StrictMode = false
+	options.BaseDir = dir
+	options.CheckData = true
+
+	// A key ID relative to the record set refers to "main/city".
+	metadata.RecordSets[0].Key = NewRecordSetKey("city")
+	report := ValidateMetadataWithOptions(metadata, options).Report()
+	for _, want := range []string{
+		`Key of RecordSet "main" is null in 1 row(s), e.g. rows 4`,
+		`Key of RecordSet "main" is not unique: 2 duplicate row(s), e.g. "Paris" in rows 1 and 3, "Paris" in rows 1 and 5`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+
+	metadata.RecordSets[0].Key = NewCompositeKey("main/city", "main/year")
+	report = ValidateMetadataWithOptions(metadata, options).Report()
+	want := `Key of RecordSet "main" is not unique: 1 duplicate row(s), e.g. ("Paris", "2020") in rows 1 and 5`
+	if !strings.Contains(report, want) {
+		t.Errorf("report is missing %q:\n%s", want, report)
+	}
+}
+
+func TestValidateCheckDataReferences(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "images.csv", "id,file_name\n1,a.jpg\n2,b.jpg\n")
+	writeTestFile(t, dir, "annotations.csv", "label,image_id\ncat,2\ndog,\nbird,3\nfish,4\n")
+
+	options := DefaultValidationOptions()
+	options.StrictMode = false
+	options.BaseDir = dir
+	options.CheckData = true
+
+	report := ValidateMetadataWithOptions(joinTestMetadata(), options).Report()
+	want := `[Metadata(join) > RecordSet(annotations) > Field(image_id)] Field "image_id" has 2 value(s) without a matching record in RecordSet "images", e.g. row 3: "3", row 4: "4"`
+	if !strings.Contains(report, want) {
+		t.Errorf("report is missing %q:\n%s", want, report)
+	}
+}
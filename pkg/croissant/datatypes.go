@@ -3,12 +3,8 @@
 package croissant
 
 import (
-	"net/url"
-	"regexp"
 	"slices"
-	"strconv"
 	"strings"
-	"time"
 )
 
 // Schema.org data types.
@@ -18,6 +14,7 @@ const VT_scInt string = "sc:Integer"
 const VT_scNum string = "sc:Number"
 const VT_scFloat string = "sc:Float"
 const VT_scDateT string = "sc:DateTime"
+const VT_scDate string = "sc:Date"
 const VT_scURL string = "sc:URL"
 const VT_scImage string = "sc:ImageObject"
 const VT_scVideo string = "sc:VideoObject"
@@ -40,6 +37,9 @@ const VT_crSplitTest string = "cr:TestSplit"
 const VT_wdPrefix string = "wd:Q"
 
 // InferDataType infers the schema.org data type from a value.
+// Values are tried against the registered value types of sc:Boolean, sc:Integer,
+// sc:Number, sc:DateTime and sc:URL, in that order; other values, e-mail addresses
+// included, are sc:Text.
 func InferDataType(value string) string {
 	// Trim whitespace
 	value = strings.TrimSpace(value)
@@ -47,46 +47,8 @@ func InferDataType(value string) string {
 		return VT_scText
 	}
 
-	// Try to parse as boolean
-	lowerVal := strings.ToLower(value)
-	if lowerVal == "true" || lowerVal == "false" {
-		return VT_scBool
-	}
-
-	// Try to parse as integer
-	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
-		return VT_scInt
-	}
-
-	// Try to parse as float
-	if _, err := strconv.ParseFloat(value, 64); err == nil {
-		return VT_scNum
-	}
-
-	// Try to parse as date (various formats)
-	dateFormats := []string{
-		"2006-01-02",
-		"01/02/2006",
-		"2006/01/02",
-		"2006-01-02T15:04:05",
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05-07:00",
-	}
-	for _, format := range dateFormats {
-		if _, err := time.Parse(format, value); err == nil {
-			return VT_scDateT
-		}
-	}
-
-	// Try to parse as URL
-	if _, err := url.ParseRequestURI(value); err == nil && (strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")) {
-		return VT_scURL
-	}
-
-	// Try to detect email
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if emailRegex.MatchString(value) {
-		return VT_scText // Email is still text but we could add a custom type if needed
+	if dataType, inferred := inferValueType(value); inferred {
+		return dataType
 	}
 
 	// Default to Text
@@ -103,6 +65,7 @@ func IsValidDataType(dataType string) bool {
 		VT_scNum:      true,
 		VT_scFloat:    true,
 		VT_scDateT:    true,
+		VT_scDate:     true,
 		VT_scURL:      true,
 		VT_scImage:    true,
 		VT_scVideo:    true,
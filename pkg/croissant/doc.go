@@ -94,6 +94,10 @@ The package automatically maps CSV content to appropriate schema.org types:
 	https://example.com       → URL           → sc:URL
 	Everything else           → Text          → sc:Text
 
+Generation profiles every row of a CSV file with ProfileCSV, in constant memory,
+and declares the narrowest type consistent with all non-null values of each
+column: Boolean ⊂ Integer ⊂ Number ⊂ Text. ColumnProfile also counts the nulls.
+
 # Validation Options
 
 Customize validation behavior using ValidationOptions:
@@ -103,10 +107,105 @@ Customize validation behavior using ValidationOptions:
 		CheckDataTypes:  true,  // Validate data type specifications
 		ValidateURLs:    false, // Skip network calls for URL validation
 		CheckFileExists: true,  // Verify referenced files exist
+		VerifyChecksums: true,  // Verify checksums and sizes of local files
+		CheckData:       true,  // Verify data values match field types
+		Offline:         true,  // Never fetch remote JSON-LD contexts
+		StrictRAI:       true,  // Warn about missing Responsible AI properties
 	}
 
 	issues, err := croissant.ValidateJSONWithOptions(data, options)
 
+The Croissant 1.0 and 1.1 JSON-LD contexts are embedded, so documents referring
+to them by URL are processed without network access. Other contexts are fetched
+and cached, unless Offline is set. A DocumentLoader serves additional contexts:
+
+	loader := croissant.NewDocumentLoader(true)
+	loader.AddDocument("https://example.com/context.jsonld", context)
+	processor := croissant.NewJSONLDProcessorWithOptions(croissant.JSONLDOptions{DocumentLoader: loader})
+
+Documents are expanded and compacted against the canonical Croissant context
+before being decoded, so documents using other prefixes, full IRIs or aliased
+terms yield the same Metadata.
+
+Properties that Metadata, Distribution, RecordSet and Field do not model, such
+as sameAs, inLanguage or citation, are kept in their Extra map and
+written back when encoding, so metadata can be loaded, edited and saved without
+losing information.
+
+Creators and publishers are Agents, each a Person or an Organization with a
+name, email, url, affiliation and identifier such as an ORCID iD. The validator
+checks their type, emails, URLs and the checksum of ORCID iDs:
+
+	author := croissant.NewPerson("Jane Doe")
+	author.Email = "jane@example.com"
+	author.Affiliation = croissant.Agents{croissant.NewOrganization("Example University")}
+	metadata.Creator = append(metadata.Creator, author)
+
+Keywords are free text or DefinedTerms from a vocabulary, such as Wikidata.
+Keywords.Strings returns the text of each keyword for search and indexing:
+
+	metadata.Keywords = append(croissant.NewKeywords("geography"),
+		croissant.NewDefinedTermKeyword("artificial intelligence", "Q11660", "https://www.wikidata.org/"))
+
+The Responsible AI (RAI) properties, such as rai:dataCollection, rai:dataBiases
+or rai:personalSensitiveInformation, are modelled by the RAI struct embedded in
+Metadata. The validator checks that the "rai" prefix is declared and that
+rai:dataCollectionTimeframe holds dates; with StrictRAI, it also warns about
+missing recommended RAI properties.
+
+# Building Metadata
+
+NewDataset builds metadata with a fluent API. IDs are generated from names and
+made unique, fields refer to the distribution of their record set, and keys
+refer to fields by name. Build reports mistakes and validation errors:
+
+	metadata, err := croissant.NewDataset("cities").
+		AddFileObject("cities.csv", "data/cities.csv", "text/csv").
+		AddRecordSet("cities").
+		AddField("name", croissant.VT_scText).
+		AddField("population", croissant.VT_scInt).
+		SetKey("name").
+		Build()
+
+# Verifying Files
+
+VerifyDistributions hashes the local files of every distribution, including the
+files of FileSets, on several workers, and reports the FileObjects whose sha256,
+md5 or contentSize does not match their file:
+
+	options := croissant.DefaultVerifyOptions()
+	options.BaseDir = "data"
+	results, err := croissant.VerifyDistributions(ctx, *metadata, options)
+
+# Reading Records
+
+Iterate over the rows a RecordSet describes, with values converted per field DataType:
+
+	metadata, _ := croissant.LoadMetadataFromFile("data/metadata.jsonld")
+	options := croissant.RecordOptions{BaseDir: "data"}
+
+	for record, err := range croissant.RecordsWithOptions(ctx, *metadata, "main", options) {
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(record["main/transaction_id"])
+	}
+
+Fields are extracted by column from CSV, TSV and Parquet files, and by jsonPath
+from JSON and JSON Lines files. Distributions may be FileSets, and may be
+contained in zip or tar archives. The fileProperty extraction yields the path,
+name, content (as []byte for sc:ImageObject fields) or lines of each file.
+Fields with subFields yield a nested Record, and repeated fields a slice.
+Record sets with inline data, such as enumerations and splits, yield their
+data rows typed per field like any other record set.
+
+Values are converted according to the first type of the field's dataType that
+has a registered ValueType: int64 for sc:Integer, float64 for sc:Float and
+sc:Number, bool for sc:Boolean, time.Time for sc:Date and sc:DateTime,
+*url.URL for sc:URL, GeoCoordinates for sc:GeoCoordinates and BoundingBox for
+cr:BoundingBox. Other values are text. RegisterValueType adds custom types, and
+ParseValue and FormatValue convert single values to and from text.
+
 # Schema Compatibility Rules
 
 When comparing metadata files, the following rules apply:
@@ -150,6 +249,8 @@ This package includes a command-line tool (gocroissant) that provides:
   - validate: Validate existing metadata files
   - match: Compare metadata files for compatibility
   - info: Analyze CSV file structure
+  - load: Preview and export the records of a record set
+  - verify: Verify distribution files against their checksums and sizes
   - version: Display version information
 
 # Specification Compliance
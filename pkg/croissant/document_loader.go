@@ -0,0 +1,121 @@
+// document_loader.go
+// Loads JSON-LD contexts from embedded copies, a cache, or the network.
+package croissant
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"sync"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+//go:embed contexts/*.jsonld
+var embeddedContexts embed.FS //nolint:gochecknoglobals
+
+// embeddedContextFiles maps the URLs of the Croissant contexts to their embedded copy.
+var embeddedContextFiles = map[string]string{ //nolint:gochecknoglobals
+	"http://mlcommons.org/croissant/1.0":  "contexts/croissant-1.0.jsonld",
+	"https://mlcommons.org/croissant/1.0": "contexts/croissant-1.0.jsonld",
+	"http://mlcommons.org/croissant/1.1":  "contexts/croissant-1.1.jsonld",
+	"https://mlcommons.org/croissant/1.1": "contexts/croissant-1.1.jsonld",
+}
+
+// remoteDocuments caches the JSON of the documents fetched by every online DocumentLoader, by URL.
+var remoteDocuments sync.Map //nolint:gochecknoglobals
+
+// cachedDocument is a fetched document, kept as JSON so that each load returns a new copy.
+type cachedDocument struct {
+	documentURL string
+	contextURL  string
+	data        []byte
+}
+
+// DocumentLoader is an ld.DocumentLoader for Croissant documents.
+//
+// The Croissant 1.0 and 1.1 contexts are served from an embedded copy, so documents
+// that refer to them by URL are processed without network access. Documents added
+// with AddDocument are served as is. Other documents are fetched over the network,
+// unless the loader is offline, and cached for the lifetime of the process. An offline
+// loader does not read that cache, so it loads the same documents wherever it runs.
+// A DocumentLoader is safe for concurrent use.
+type DocumentLoader struct {
+	mu        sync.RWMutex
+	documents map[string]*ld.RemoteDocument
+	remote    ld.DocumentLoader
+}
+
+// NewDocumentLoader creates a document loader. An offline loader never accesses
+// the network and fails to load documents that are neither embedded nor added.
+func NewDocumentLoader(offline bool) *DocumentLoader {
+	loader := &DocumentLoader{documents: make(map[string]*ld.RemoteDocument)}
+	if !offline {
+		loader.remote = ld.NewDefaultDocumentLoader(nil)
+	}
+
+	return loader
+}
+
+// AddDocument serves a document, such as a JSON-LD context, for the given URL.
+// The document is the parsed JSON, e.g. map[string]interface{}{"@context": ...}.
+func (l *DocumentLoader) AddDocument(url string, document interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.documents[url] = &ld.RemoteDocument{DocumentURL: url, Document: document}
+}
+
+// LoadDocument returns the document at the given URL. It implements ld.DocumentLoader.
+func (l *DocumentLoader) LoadDocument(url string) (*ld.RemoteDocument, error) {
+	l.mu.RLock()
+	document, exists := l.documents[url]
+	l.mu.RUnlock()
+	if exists {
+		return document, nil
+	}
+
+	if file, embedded := embeddedContextFiles[url]; embedded {
+		return loadEmbeddedContext(url, file)
+	}
+
+	if l.remote == nil {
+		return nil, CroissantError{Message: "remote JSON-LD documents cannot be loaded offline", Value: url}
+	}
+	if cached, exists := remoteDocuments.Load(url); exists {
+		cachedDoc := cached.(cachedDocument) //nolint:forcetypeassert
+		return parseDocument(cachedDoc.documentURL, cachedDoc.contextURL, cachedDoc.data)
+	}
+
+	document, err := l.remote.LoadDocument(url)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to load JSON-LD document", Value: err}
+	}
+	data, err := json.Marshal(document.Document)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to cache JSON-LD document", Value: err}
+	}
+	remoteDocuments.Store(url, cachedDocument{documentURL: document.DocumentURL, contextURL: document.ContextURL, data: data})
+
+	return document, nil
+}
+
+// loadEmbeddedContext parses an embedded context. Each load returns a new copy,
+// so that a document modified by its user is not shared.
+func loadEmbeddedContext(url string, file string) (*ld.RemoteDocument, error) {
+	data, err := embeddedContexts.ReadFile(file)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to read embedded context", Value: err}
+	}
+
+	return parseDocument(url, "", data)
+}
+
+// parseDocument parses the JSON of a document into a new ld.RemoteDocument.
+func parseDocument(documentURL string, contextURL string, data []byte) (*ld.RemoteDocument, error) {
+	document, err := ld.DocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, CroissantError{Message: "failed to parse JSON-LD document", Value: err}
+	}
+
+	return &ld.RemoteDocument{DocumentURL: documentURL, Document: document, ContextURL: contextURL}, nil
+}
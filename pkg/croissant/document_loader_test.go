@@ -0,0 +1,69 @@
+// File: pkg/croissant/document_loader_test.go
+package croissant
+
+import (
+	"testing"
+)
+
+func TestDocumentLoaderEmbeddedContexts(t *testing.T) {
+	processor := NewJSONLDProcessorWithOptions(JSONLDOptions{Offline: true})
+	for _, contextURL := range []string{"http://mlcommons.org/croissant/1.0", "http://mlcommons.org/croissant/1.1"} {
+		document := `{"@context": "` + contextURL + `", "@type": "sc:Dataset", "name": "test", "recordSet": [{"@type": "cr:RecordSet", "name": "rs"}]}`
+		expanded, err := processor.ParseJSONLD([]byte(document))
+		if err != nil {
+			t.Fatalf("ParseJSONLD with context %s offline: %v", contextURL, err)
+		}
+		if name := GetPropertyValue(expanded["https://schema.org/name"]); name != "test" {
+			t.Errorf("expanded name = %q, want test", name)
+		}
+		if _, exists := expanded["http://mlcommons.org/croissant/recordSet"]; !exists {
+			t.Errorf("expanded document has no cr:recordSet: %v", expanded)
+		}
+	}
+}
+
+func TestDocumentLoaderOffline(t *testing.T) {
+	document := []byte(`{"@context": "https://example.com/context.jsonld", "name": "test"}`)
+
+	processor := NewJSONLDProcessorWithOptions(JSONLDOptions{Offline: true})
+	if err := processor.ValidateJSONLD(document); err == nil {
+		t.Error("a remote context should not be loaded offline")
+	}
+
+	loader := NewDocumentLoader(true)
+	loader.AddDocument("https://example.com/context.jsonld", map[string]interface{}{
+		"@context": map[string]interface{}{"name": "https://example.com/name"},
+	})
+	processor = NewJSONLDProcessorWithOptions(JSONLDOptions{DocumentLoader: loader})
+	expanded, err := processor.ParseJSONLD(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := GetPropertyValue(expanded["https://example.com/name"]); name != "test" {
+		t.Errorf("expanded name = %q, want test", name)
+	}
+}
+
+func TestDocumentLoaderCache(t *testing.T) {
+	url := "https://example.com/cached.jsonld"
+	remoteDocuments.Store(url, cachedDocument{documentURL: url, data: []byte(`{"@context": {"name": "https://example.com/name"}}`)})
+	defer remoteDocuments.Delete(url)
+
+	if _, err := NewDocumentLoader(true).LoadDocument(url); err == nil {
+		t.Error("an offline loader should not serve cached remote documents")
+	}
+
+	loader := NewDocumentLoader(false)
+	first, err := loader.LoadDocument(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Document.(map[string]interface{})["@context"] = "modified"
+	second, err := loader.LoadDocument(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, isMap := second.Document.(map[string]interface{})["@context"].(map[string]interface{}); !isMap {
+		t.Errorf("cached document was modified through a previous load: %v", second.Document)
+	}
+}
@@ -0,0 +1,253 @@
+// extra_properties.go
+// Keeps the properties that structs do not model, so metadata round-trips without loss.
+package croissant
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// knownPropertiesByType caches the JSON property names of struct types.
+var knownPropertiesByType sync.Map //nolint:gochecknoglobals
+
+// knownProperties returns the JSON property names of the fields of a struct type.
+func knownProperties(structType reflect.Type) map[string]bool {
+	if cached, exists := knownPropertiesByType.Load(structType); exists {
+		return cached.(map[string]bool) //nolint:forcetypeassert
+	}
+
+	properties := make(map[string]bool)
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// Properties of embedded structs are promoted.
+			maps.Copy(properties, knownProperties(field.Type))
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = true
+	}
+	knownPropertiesByType.Store(structType, properties)
+
+	return properties
+}
+
+// unmarshalWithExtra decodes data into target, a pointer to a struct without custom
+// unmarshaling, and returns the properties of data that the struct does not model.
+// Numbers in the returned properties are json.Number, to keep their exact value.
+func unmarshalWithExtra(data []byte, target interface{}) (map[string]interface{}, error) {
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var properties map[string]interface{}
+	if err := decoder.Decode(&properties); err != nil {
+		return nil, err
+	}
+
+	known := knownProperties(reflect.TypeOf(target).Elem())
+	maps.DeleteFunc(properties, func(name string, _ interface{}) bool {
+		return known[name]
+	})
+	if len(properties) == 0 {
+		return nil, nil
+	}
+
+	return properties, nil
+}
+
+// marshalWithExtra encodes value, a struct without custom marshaling, followed by the
+// extra properties in name order. Extra properties the struct models are ignored.
+func marshalWithExtra(value interface{}, extra map[string]interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil || len(extra) == 0 {
+		return encoded, err
+	}
+
+	known := knownProperties(reflect.TypeOf(value))
+	var buffer bytes.Buffer
+	buffer.Write(encoded[:len(encoded)-1])
+	separator := len(encoded) > 2
+	for _, name := range slices.Sorted(maps.Keys(extra)) {
+		if known[name] {
+			continue
+		}
+		encodedName, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(extra[name])
+		if err != nil {
+			return nil, CroissantError{Message: "failed to marshal property " + name, Value: err}
+		}
+		if separator {
+			buffer.WriteByte(',')
+		}
+		separator = true
+		buffer.Write(encodedName)
+		buffer.WriteByte(':')
+		buffer.Write(encodedValue)
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Metadata, keeping unmodelled properties in Extra.
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type plainMetadata Metadata
+	var plain plainMetadata
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*m = Metadata(plain)
+	m.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for Metadata, including the properties in Extra.
+func (m Metadata) MarshalJSON() ([]byte, error) {
+	type plainMetadata Metadata
+	return marshalWithExtra(plainMetadata(m), m.Extra)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Context, keeping unmodelled terms in Extra.
+func (c *Context) UnmarshalJSON(data []byte) error {
+	type plainContext Context
+	var plain plainContext
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*c = Context(plain)
+	c.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for Context, including the terms in Extra.
+func (c Context) MarshalJSON() ([]byte, error) {
+	type plainContext Context
+	return marshalWithExtra(plainContext(c), c.Extra)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Distribution, keeping unmodelled properties in Extra.
+func (d *Distribution) UnmarshalJSON(data []byte) error {
+	type plainDistribution Distribution
+	var plain plainDistribution
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*d = Distribution(plain)
+	d.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for Distribution, including the properties in Extra.
+func (d Distribution) MarshalJSON() ([]byte, error) {
+	type plainDistribution Distribution
+	return marshalWithExtra(plainDistribution(d), d.Extra)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for RecordSet, keeping unmodelled properties in Extra.
+func (rs *RecordSet) UnmarshalJSON(data []byte) error {
+	type plainRecordSet RecordSet
+	var plain plainRecordSet
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*rs = RecordSet(plain)
+	rs.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for RecordSet, including the properties in Extra.
+func (rs RecordSet) MarshalJSON() ([]byte, error) {
+	type plainRecordSet RecordSet
+	return marshalWithExtra(plainRecordSet(rs), rs.Extra)
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Field, keeping unmodelled properties in Extra.
+func (f *Field) UnmarshalJSON(data []byte) error {
+	type plainField Field
+	var plain plainField
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*f = Field(plain)
+	f.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for Field, including the properties in Extra.
+func (f Field) MarshalJSON() ([]byte, error) {
+	type plainField Field
+	return marshalWithExtra(plainField(f), f.Extra)
+}
+
+// validateUncompactedProperties reports the properties kept in Extra that are full IRIs, i.e.
+// that are not terms of the Croissant context, such as properties of an outdated namespace.
+// A property named like a modelled one is an error, as the modelled property is then missing.
+func validateUncompactedProperties(node *MetadataNode, issues *Issues) {
+	metadata := node.metadata
+	reportUncompactedProperties(metadata.Extra, reflect.TypeFor[Metadata](), node, issues)
+	for i, dist := range metadata.Distributions {
+		if i < len(node.Distributions) {
+			reportUncompactedProperties(dist.Extra, reflect.TypeFor[Distribution](), node.Distributions[i], issues)
+		}
+	}
+	for i, rs := range metadata.RecordSets {
+		if i < len(node.RecordSets) {
+			reportUncompactedProperties(rs.Extra, reflect.TypeFor[RecordSet](), node.RecordSets[i], issues)
+			reportUncompactedFieldProperties(rs.Fields, node.RecordSets[i].Fields, issues)
+		}
+	}
+}
+
+// reportUncompactedFieldProperties reports the uncompacted properties of fields and their subFields.
+func reportUncompactedFieldProperties(fields []Field, nodes []*FieldNode, issues *Issues) {
+	for i, field := range fields {
+		if i < len(nodes) {
+			reportUncompactedProperties(field.Extra, reflect.TypeFor[Field](), nodes[i], issues)
+			reportUncompactedFieldProperties(field.SubField, nodes[i].SubField, issues)
+		}
+	}
+}
+
+// reportUncompactedProperties reports the properties of extra that are full IRIs.
+func reportUncompactedProperties(extra map[string]interface{}, structType reflect.Type, node Node, issues *Issues) {
+	known := knownProperties(structType)
+	for _, name := range slices.Sorted(maps.Keys(extra)) {
+		if !strings.Contains(name, "://") {
+			continue
+		}
+		term := name[strings.LastIndexAny(name, "/#")+1:]
+		if known[term] {
+			issues.AddError(fmt.Sprintf("Property \"%s\" is not part of the Croissant vocabulary, and is ignored. Did you mean \"%s\"?", name, term), node)
+		} else {
+			issues.AddWarning(fmt.Sprintf("Property \"%s\" is not part of the Croissant vocabulary, and is ignored.", name), node)
+		}
+	}
+}
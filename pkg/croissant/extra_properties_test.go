@@ -0,0 +1,103 @@
+// File: pkg/croissant/extra_properties_test.go
+package croissant
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtraPropertiesRoundTrip(t *testing.T) {
+	context := CreateDefaultContext()
+	context.RAI = RAIPrefix
+	context.Extra = map[string]interface{}{"ex": "https://example.com/"}
+	contextJSON, err := json.Marshal(context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := `{
+		"@context": ` + string(contextJSON) + `,
+		"@type": "sc:Dataset",
+		"name": "cities",
+		"conformsTo": "http://mlcommons.org/croissant/1.0",
+		"sameAs": "https://example.com/cities",
+		"inLanguage": ["en", "fr"],
+		"isAccessibleForFree": true,
+		"ex:rating": 12345678901234567890,
+		"distribution": [{"@type": "cr:FileObject", "@id": "cities.csv", "name": "cities.csv", "contentUrl": "cities.csv",
+			"encodingFormat": "text/csv", "sameAs": "https://example.com/cities.csv"}],
+		"recordSet": [{"@type": "cr:RecordSet", "@id": "cities", "name": "cities", "examples": [{"name": "Paris"}],
+			"field": [{"@type": "cr:Field", "@id": "cities/name", "name": "name", "dataType": "sc:Text",
+				"source": {"fileObject": {"@id": "cities.csv"}, "extract": {"column": "name"}},
+				"citation": "Atlas, 2024"}]}]
+	}`
+
+	metadata, err := NewJSONLDProcessor().ParseCroissantMetadata([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := metadata.Extra["isAccessibleForFree"]; got != true {
+		t.Errorf("Extra[isAccessibleForFree] = %v", got)
+	}
+	if got := metadata.Extra["https://example.com/rating"]; got != json.Number("12345678901234567890") {
+		t.Errorf("Extra[https://example.com/rating] = %#v, want the exact number", got)
+	}
+	if got := metadata.Context.Extra["ex"]; got != "https://example.com/" {
+		t.Errorf("Context.Extra[ex] = %v", got)
+	}
+	if got := metadata.Distributions[0].Extra["sameAs"]; got != "https://example.com/cities.csv" {
+		t.Errorf("Distribution.Extra[sameAs] = %v", got)
+	}
+	if _, exists := metadata.RecordSets[0].Extra["examples"]; !exists {
+		t.Errorf("RecordSet.Extra = %v, want examples", metadata.RecordSets[0].Extra)
+	}
+	if got := metadata.RecordSets[0].Fields[0].Extra["citation"]; got != "Atlas, 2024" {
+		t.Errorf("Field.Extra[citation] = %v", got)
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"sameAs":"https://example.com/cities"`, `"inLanguage":["en","fr"]`, `12345678901234567890`, `"citation":"Atlas, 2024"`} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("encoded metadata is missing %s: %s", want, encoded)
+		}
+	}
+
+	reparsed, err := NewJSONLDProcessor().ParseCroissantMetadata(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reparsed, metadata) {
+		t.Errorf("metadata changed after a round trip:\n%+v\nwant\n%+v", reparsed, metadata)
+	}
+}
+
+func TestSamplesRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../../samples_jsonld/*.jsonld")
+	if err != nil || len(files) == 0 {
+		t.Skip("Skipping round trip test; samples_jsonld does not exist")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			metadata, err := LoadMetadataFromFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(metadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reparsed, err := NewJSONLDProcessor().ParseCroissantMetadata(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(reparsed, metadata) {
+				t.Errorf("metadata changed after a round trip:\n%+v\nwant\n%+v", reparsed, metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// file_source.go
+// Reads file properties (paths, names, content and lines) of FileSets and FileObjects.
+package croissant
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// File properties that fields can extract from the files of a distribution.
+const (
+	// Path of the file, relative to its FileSet or archive.
+	FilePropertyFullPath string = "fullpath"
+	// Base name of the file.
+	FilePropertyFileName string = "filename"
+	// Content of the file.
+	FilePropertyContent string = "content"
+	// Each line of the file, producing one record per line.
+	FilePropertyLines string = "lines"
+	// 0-based index of each line of the file, producing one record per line.
+	FilePropertyLineNumbers string = "lineNumbers"
+)
+
+// IsValidFileProperty checks if a file property is defined by the Croissant specification.
+func IsValidFileProperty(property string) bool {
+	switch property {
+	case FilePropertyFullPath, FilePropertyFileName, FilePropertyContent, FilePropertyLines, FilePropertyLineNumbers:
+		return true
+	default:
+		return false
+	}
+}
+
+// isBinaryType returns true if file content of a DataType is kept as raw bytes rather than text.
+func isBinaryType(dataType DataType) bool {
+	types := dataType.GetTypes()
+	return slices.Contains(types, VT_scImage) || slices.Contains(types, VT_scVideo)
+}
+
+// usesFileProperties returns true if every field extracts a file property.
+func usesFileProperties(fields []Field) bool {
+	for _, field := range fields {
+		if field.Source.Extract.FileProperty == "" {
+			return false
+		}
+	}
+
+	return len(fields) > 0
+}
+
+// fileSource yields the file properties of the files of a FileSet, or of the single file of a FileObject.
+//
+// There is one row per file, or one row per line of each file when a field extracts
+// lines or lineNumbers. Content is returned as []byte for image and video fields,
+// and as a string otherwise.
+type fileSource struct {
+	next       func() (FileEntry, error, bool)
+	stop       func()
+	properties map[string]string // field key -> file property
+	binary     map[string]bool   // field key -> content kept as bytes
+	byLine     bool
+	hasContent bool
+
+	// State of the file being read line by line.
+	entry      FileEntry
+	content    []byte
+	file       io.ReadCloser
+	lines      *bufio.Reader
+	lineNumber int
+}
+
+// openFileSource resolves the files of a distribution and maps each field to the file property it extracts.
+func openFileSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions) (*fileSource, error) {
+	source := &fileSource{
+		properties: make(map[string]string, len(fields)),
+		binary:     make(map[string]bool, len(fields)),
+	}
+	for _, field := range fields {
+		property := field.Source.Extract.FileProperty
+		switch {
+		case property == "":
+			return nil, CroissantError{
+				Message: fmt.Sprintf("field %q must extract a file property from FileSet %q", FieldKey(field), dist.ID),
+			}
+		case !IsValidFileProperty(property):
+			return nil, CroissantError{
+				Message: fmt.Sprintf("unsupported file property for field %q", FieldKey(field)),
+				Value:   property,
+			}
+		}
+
+		key := FieldKey(field)
+		source.properties[key] = property
+		source.binary[key] = isBinaryType(field.DataType)
+		source.byLine = source.byLine || property == FilePropertyLines || property == FilePropertyLineNumbers
+		source.hasContent = source.hasContent || property == FilePropertyContent
+	}
+
+	source.next, source.stop = iter.Pull2(FileSetEntries(ctx, metadata, dist.ID, options.BaseDir))
+
+	return source, nil
+}
+
+// Next returns the file properties of the next file, or of the next line.
+func (s *fileSource) Next() (map[string]interface{}, error) {
+	if !s.byLine {
+		entry, err := s.nextEntry()
+		if err != nil {
+			return nil, err
+		}
+		var content []byte
+		if s.hasContent {
+			if content, err = readFileEntry(entry); err != nil {
+				return nil, err
+			}
+		}
+		return s.values(entry, content, "", 0), nil
+	}
+
+	for {
+		if s.lines == nil {
+			if err := s.openLines(); err != nil {
+				return nil, err
+			}
+		}
+
+		line, err := s.lines.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, CroissantError{Message: "failed to read file", Value: err}
+		}
+		if line == "" && err != nil {
+			// End of the current file.
+			s.closeLines()
+			continue
+		}
+
+		values := s.values(s.entry, s.content, strings.TrimRight(line, "\r\n"), s.lineNumber)
+		s.lineNumber++
+		return values, nil
+	}
+}
+
+// nextEntry advances to the next file.
+func (s *fileSource) nextEntry() (FileEntry, error) {
+	entry, err, ok := s.next()
+	if !ok {
+		return FileEntry{}, io.EOF
+	}
+	if err != nil {
+		return FileEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// openLines advances to the next file and prepares to read it line by line.
+func (s *fileSource) openLines() error {
+	entry, err := s.nextEntry()
+	if err != nil {
+		return err
+	}
+
+	s.entry = entry
+	s.lineNumber = 0
+	if s.hasContent {
+		if s.content, err = readFileEntry(entry); err != nil {
+			return err
+		}
+		s.lines = bufio.NewReader(bytes.NewReader(s.content))
+		return nil
+	}
+
+	if s.file, err = entry.Open(); err != nil {
+		return err
+	}
+	s.lines = bufio.NewReader(s.file)
+
+	return nil
+}
+
+// closeLines releases the file being read line by line.
+func (s *fileSource) closeLines() {
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	s.file, s.lines, s.content = nil, nil, nil
+}
+
+// values returns the properties of a file (and line) for every field.
+func (s *fileSource) values(entry FileEntry, content []byte, line string, lineNumber int) map[string]interface{} {
+	values := make(map[string]interface{}, len(s.properties))
+	for key, property := range s.properties {
+		switch property {
+		case FilePropertyFullPath:
+			values[key] = entry.Path
+		case FilePropertyFileName:
+			values[key] = entry.Name()
+		case FilePropertyContent:
+			if s.binary[key] {
+				values[key] = content
+			} else {
+				values[key] = string(content)
+			}
+		case FilePropertyLines:
+			values[key] = line
+		case FilePropertyLineNumbers:
+			values[key] = int64(lineNumber)
+		}
+	}
+
+	return values
+}
+
+// Close stops the underlying file iteration.
+func (s *fileSource) Close() error {
+	s.closeLines()
+	s.stop()
+	return nil
+}
+
+// readFileEntry reads the whole content of a file.
+func readFileEntry(entry FileEntry) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to read file", Value: err}
+	}
+
+	return content, nil
+}
+
+// fileObjectPropertySource adds the path and name of a FileObject to the rows read from its content,
+// for record sets that mix columns with fullpath or filename file properties.
+type fileObjectPropertySource struct {
+	recordSource
+	properties map[string]interface{}
+}
+
+// withFileObjectProperties wraps the source of a FileObject's content fields with its file property fields.
+func withFileObjectProperties(source recordSource, dist Distribution, fields []Field) (recordSource, error) {
+	fullPath := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(strings.TrimPrefix(dist.ContentURL, "file://"))), "/")
+
+	properties := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		switch property := field.Source.Extract.FileProperty; property {
+		case FilePropertyFullPath:
+			properties[FieldKey(field)] = fullPath
+		case FilePropertyFileName:
+			properties[FieldKey(field)] = path.Base(fullPath)
+		default:
+			return nil, CroissantError{
+				Message: fmt.Sprintf("file property of field %q cannot be combined with other extractions from distribution %q", FieldKey(field), dist.ID),
+				Value:   property,
+			}
+		}
+	}
+
+	return &fileObjectPropertySource{recordSource: source, properties: properties}, nil
+}
+
+// Next returns the next row of the content with the file properties added.
+func (s *fileObjectPropertySource) Next() (map[string]interface{}, error) {
+	values, err := s.recordSource.Next()
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range s.properties {
+		values[key] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,130 @@
+// File: pkg/croissant/file_source_test.go
+package croissant
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// fileSourceTestMetadata returns metadata with a FileSet of text files and a record set
+// whose fields extract the given file properties.
+func fileSourceTestMetadata(properties map[string]string) Metadata {
+	var fields []Field
+	for _, name := range []string{"path", "name", "text", "image", "line", "number"} {
+		property, ok := properties[name]
+		if !ok {
+			continue
+		}
+		dataType := VT_scText
+		switch name {
+		case "image":
+			dataType = VT_scImage
+		case "number":
+			dataType = VT_scInt
+		}
+		fields = append(fields, Field{
+			ID:       "files/" + name,
+			Type:     "cr:Field",
+			Name:     name,
+			DataType: NewSingleDataType(dataType),
+			Source: FieldSource{
+				FileSet: FileObject{ID: "texts"},
+				Extract: Extract{FileProperty: property},
+			},
+		})
+	}
+
+	return Metadata{
+		Type: "sc:Dataset",
+		Name: "files",
+		Distributions: []Distribution{
+			{ID: "texts", Type: "cr:FileSet", Name: "texts", ContentURL: "texts", EncodingFormat: "text/plain", Includes: "*.txt"},
+		},
+		RecordSets: []RecordSet{
+			{ID: "files", Type: "cr:RecordSet", Name: "files", Fields: fields},
+		},
+	}
+}
+
+func readTestRecords(t *testing.T, metadata Metadata, recordSetID string, baseDir string) []Record {
+	t.Helper()
+	var records []Record
+	for record, err := range RecordsWithOptions(context.Background(), metadata, recordSetID, RecordOptions{BaseDir: baseDir}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestRecordsFileContent(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "texts/sub/a.txt", "alpha")
+
+	metadata := fileSourceTestMetadata(map[string]string{
+		"path":  "fullpath",
+		"name":  "filename",
+		"text":  "content",
+		"image": "content",
+	})
+	records := readTestRecords(t, metadata, "files", dir)
+
+	want := []Record{
+		{"files/path": "sub/a.txt", "files/name": "a.txt", "files/text": "alpha", "files/image": []byte("alpha")},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
+
+func TestRecordsFileLines(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "texts/a.txt", "first\r\nsecond\n")
+	writeTestFile(t, dir, "texts/empty.txt", "")
+
+	metadata := fileSourceTestMetadata(map[string]string{
+		"name":   "filename",
+		"line":   "lines",
+		"number": "lineNumbers",
+	})
+	records := readTestRecords(t, metadata, "files", dir)
+
+	want := []Record{
+		{"files/name": "a.txt", "files/line": "first", "files/number": int64(0)},
+		{"files/name": "a.txt", "files/line": "second", "files/number": int64(1)},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
+
+func TestRecordsFileObjectProperties(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data/people.csv", "name\nAlice\nBob\n")
+
+	metadata := csvTestMetadata(csvTestField("name", VT_scText))
+	metadata.Distributions[0].ContentURL = "data/people.csv"
+	metadata.RecordSets[0].Fields = append(metadata.RecordSets[0].Fields, Field{
+		ID:       "main/file",
+		Type:     "cr:Field",
+		Name:     "file",
+		DataType: NewSingleDataType(VT_scText),
+		Source: FieldSource{
+			FileObject: FileObject{ID: metadata.Distributions[0].ID},
+			Extract:    Extract{FileProperty: "filename"},
+		},
+	})
+
+	records := readTestRecords(t, metadata, "main", dir)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	for _, record := range records {
+		if record["main/file"] != "people.csv" {
+			t.Errorf("file = %v, want people.csv", record["main/file"])
+		}
+	}
+}
@@ -0,0 +1,382 @@
+// fileset.go
+// Resolves the concrete files covered by FileSet distributions.
+package croissant
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileEntry is a single file belonging to a distribution.
+type FileEntry struct {
+	// Path of the file relative to the root of its FileSet, using forward slashes.
+	Path string
+	// Local path of the file. For members of an archive, the member path joined to the archive path.
+	FullPath string
+	// Local path of the archive containing the file, if any.
+	Archive string
+	// Size of the file in bytes.
+	Size int64
+	open func() (io.ReadCloser, error)
+}
+
+// Open opens the file for reading. The caller must close the returned reader.
+// Members of an archive can only be opened while iterating with FileSetEntries,
+// before the next entry is requested.
+func (e FileEntry) Open() (io.ReadCloser, error) {
+	if e.open == nil {
+		return nil, CroissantError{Message: "file entry cannot be opened", Value: e.Path}
+	}
+
+	return e.open()
+}
+
+// Name returns the base name of the file.
+func (e FileEntry) Name() string {
+	return path.Base(e.Path)
+}
+
+// ResolveFileSet returns every file covered by a FileSet (or the single file of a FileObject).
+// Relative content URLs are resolved against baseDir. A FileSet without containedIn
+// or contentUrl covers the files of baseDir, which must then be given.
+//
+// Files are matched against the FileSet's includes glob and filtered by its excludes glob.
+// Globs without a "/" match file names in any directory; "**" matches across directories.
+// A FileSet contained in a zip, tar or tar.gz FileObject lists the members of the archive;
+// use FileSetEntries to read their content.
+//
+// Example:
+//
+//	files, err := croissant.ResolveFileSet(*metadata, "image-files", "data")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, file := range files {
+//		fmt.Println(file.Path, file.Size)
+//	}
+func ResolveFileSet(metadata Metadata, distributionID string, baseDir string) ([]FileEntry, error) {
+	var entries []FileEntry
+	for entry, err := range FileSetEntries(context.Background(), metadata, distributionID, baseDir) {
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// FileSetEntries returns an iterator over the files covered by a distribution.
+// See ResolveFileSet for matching rules.
+func FileSetEntries(ctx context.Context, metadata Metadata, distributionID string, baseDir string) iter.Seq2[FileEntry, error] {
+	return func(yield func(FileEntry, error) bool) {
+		dist := findDistribution(metadata, distributionID)
+		if dist == nil {
+			yield(FileEntry{}, CroissantError{Message: "distribution not found", Value: distributionID})
+			return
+		}
+
+		resolver := &fileSetResolver{
+			ctx:      ctx,
+			metadata: metadata,
+			baseDir:  baseDir,
+			visiting: make(map[string]bool),
+		}
+		resolver.walk(*dist, yield)
+	}
+}
+
+// fileSetResolver walks distributions, following containedIn references.
+type fileSetResolver struct {
+	ctx      context.Context
+	metadata Metadata
+	baseDir  string
+	// Distributions currently being walked, to detect containedIn cycles.
+	visiting map[string]bool
+}
+
+// walk yields the files of a distribution. Returns false if iteration was stopped.
+func (r *fileSetResolver) walk(dist Distribution, yield func(FileEntry, error) bool) bool {
+	if r.visiting[dist.ID] {
+		return yield(FileEntry{}, CroissantError{Message: "containedIn references form a cycle", Value: dist.ID})
+	}
+	r.visiting[dist.ID] = true
+	defer delete(r.visiting, dist.ID)
+
+	if dist.Type != "cr:FileSet" {
+		return r.walkFileObject(dist, yield)
+	}
+
+	filter, err := newFileSetFilter(dist)
+	if err != nil {
+		return yield(FileEntry{}, err)
+	}
+
+	// The files of a FileSet are found in its container, its content URL, or the base directory.
+	var files iter.Seq2[FileEntry, error]
+	switch {
+	case dist.ContainedIn != nil && dist.ContainedIn.ID != "":
+		container := findDistribution(r.metadata, dist.ContainedIn.ID)
+		if container == nil {
+			return yield(FileEntry{}, CroissantError{
+				Message: fmt.Sprintf("distribution %q is contained in a non-existent distribution", dist.ID),
+				Value:   dist.ContainedIn.ID,
+			})
+		}
+		files = func(inner func(FileEntry, error) bool) { r.walkContainer(*container, inner) }
+	case dist.ContentURL != "":
+		root, err := resolveContentPath(dist, r.baseDir)
+		if err != nil {
+			return yield(FileEntry{}, err)
+		}
+		files = r.walkDirectory(root)
+	default:
+		if r.baseDir == "" {
+			return yield(FileEntry{}, CroissantError{
+				Message: fmt.Sprintf("distribution %q has no containedIn or contentUrl, and no base directory is given", dist.ID),
+				Value:   dist.Includes,
+			})
+		}
+		files = r.walkDirectory(r.baseDir)
+	}
+
+	for entry, err := range files {
+		if err != nil {
+			return yield(FileEntry{}, err)
+		}
+		if !filter.Match(entry.Path) {
+			continue
+		}
+		if !yield(entry, nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// walkContainer yields the files inside the distribution a FileSet is contained in.
+func (r *fileSetResolver) walkContainer(container Distribution, yield func(FileEntry, error) bool) bool {
+	if container.Type == "cr:FileSet" {
+		return r.walk(container, yield)
+	}
+
+	root, err := resolveContentPath(container, r.baseDir)
+	if err != nil {
+		return yield(FileEntry{}, err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return yield(FileEntry{}, CroissantError{Message: "failed to get file info", Value: err})
+	}
+
+	files := r.walkDirectory(root)
+	if !info.IsDir() {
+		kind := detectArchiveKind(container, root)
+		if kind == notArchive {
+			return yield(FileEntry{}, CroissantError{
+				Message: fmt.Sprintf("unsupported container format for distribution %q", container.ID),
+				Value:   container.EncodingFormat,
+			})
+		}
+		files = walkArchive(r.ctx, root, kind)
+	}
+
+	for entry, err := range files {
+		if !yield(entry, err) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// walkFileObject yields the single file of a FileObject.
+// A FileObject contained in another distribution is looked up by its content URL among the
+// files of its container.
+func (r *fileSetResolver) walkFileObject(dist Distribution, yield func(FileEntry, error) bool) bool {
+	if dist.ContainedIn != nil && dist.ContainedIn.ID != "" {
+		container := findDistribution(r.metadata, dist.ContainedIn.ID)
+		if container == nil {
+			return yield(FileEntry{}, CroissantError{
+				Message: fmt.Sprintf("distribution %q is contained in a non-existent distribution", dist.ID),
+				Value:   dist.ContainedIn.ID,
+			})
+		}
+
+		memberPath := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(dist.ContentURL)), "/")
+		for entry, err := range func(inner func(FileEntry, error) bool) { r.walkContainer(*container, inner) } {
+			if err != nil {
+				return yield(FileEntry{}, err)
+			}
+			if entry.Path == memberPath {
+				return yield(entry, nil)
+			}
+		}
+
+		return yield(FileEntry{}, CroissantError{
+			Message: fmt.Sprintf("file not found in distribution %q", container.ID),
+			Value:   dist.ContentURL,
+		})
+	}
+
+	fullPath, err := resolveContentPath(dist, r.baseDir)
+	if err != nil {
+		return yield(FileEntry{}, err)
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return yield(FileEntry{}, CroissantError{Message: "failed to get file info", Value: err})
+	}
+
+	return yield(localFileEntry(filepath.ToSlash(dist.ContentURL), fullPath, info.Size()), nil)
+}
+
+// walkDirectory returns an iterator over the regular files below a local directory.
+func (r *fileSetResolver) walkDirectory(root string) iter.Seq2[FileEntry, error] {
+	return func(yield func(FileEntry, error) bool) {
+		stopped := false
+		err := filepath.WalkDir(root, func(fullPath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := r.ctx.Err(); err != nil {
+				return err
+			}
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(root, fullPath)
+			if err != nil {
+				return err
+			}
+
+			if !yield(localFileEntry(filepath.ToSlash(relPath), fullPath, info.Size()), nil) {
+				stopped = true
+				return fs.SkipAll
+			}
+			return nil
+		})
+		if err != nil && !stopped {
+			yield(FileEntry{}, CroissantError{Message: "failed to list files", Value: err})
+		}
+	}
+}
+
+// localFileEntry returns a FileEntry for a file on the local file system.
+func localFileEntry(relPath string, fullPath string, size int64) FileEntry {
+	return FileEntry{
+		Path:     relPath,
+		FullPath: fullPath,
+		Size:     size,
+		open: func() (io.ReadCloser, error) {
+			file, err := os.Open(filepath.Clean(fullPath))
+			if err != nil {
+				return nil, CroissantError{Message: "failed to open file", Value: err}
+			}
+			return file, nil
+		},
+	}
+}
+
+// fileSetFilter matches paths against the includes and excludes globs of a FileSet.
+type fileSetFilter struct {
+	includes *regexp.Regexp
+	excludes *regexp.Regexp
+}
+
+// newFileSetFilter compiles the globs of a FileSet. A FileSet without includes matches every file.
+func newFileSetFilter(dist Distribution) (*fileSetFilter, error) {
+	filter := &fileSetFilter{}
+
+	var err error
+	if dist.Includes != "" {
+		if filter.includes, err = compileGlob(dist.Includes); err != nil {
+			return nil, CroissantError{Message: fmt.Sprintf("invalid includes glob for distribution %q", dist.ID), Value: err}
+		}
+	}
+	if dist.Excludes != "" {
+		if filter.excludes, err = compileGlob(dist.Excludes); err != nil {
+			return nil, CroissantError{Message: fmt.Sprintf("invalid excludes glob for distribution %q", dist.ID), Value: err}
+		}
+	}
+
+	return filter, nil
+}
+
+// Match returns true if a slash-separated path is included and not excluded.
+func (f *fileSetFilter) Match(relPath string) bool {
+	if f.includes != nil && !f.includes.MatchString(relPath) {
+		return false
+	}
+	if f.excludes != nil && f.excludes.MatchString(relPath) {
+		return false
+	}
+
+	return true
+}
+
+// compileGlob converts a glob pattern to a regular expression matching slash-separated paths.
+//
+// Supported syntax: "*" (any characters except "/"), "**" (any characters including "/"),
+// "?" (one character except "/"), and "[...]" character classes.
+// Patterns without a "/" match the file name in any directory.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !strings.Contains(pattern, "/") {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		char := pattern[i]
+		switch char {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					expr.WriteString("(?:.*/)?")
+				} else {
+					expr.WriteString(".*")
+				}
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, CroissantError{Message: "unterminated character class in glob", Value: pattern}
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
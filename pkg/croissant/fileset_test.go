@@ -0,0 +1,143 @@
+// File: pkg/croissant/fileset_test.go
+package croissant
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestCompileGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.jpg", "a.jpg", true},
+		{"*.jpg", "train2014/a.jpg", true},
+		{"*.jpg", "a.png", false},
+		{"train2014/*.jpg", "train2014/a.jpg", true},
+		{"train2014/*.jpg", "val2014/a.jpg", false},
+		{"train2014/*.jpg", "train2014/sub/a.jpg", false},
+		{"**/*.json", "a/b/c.json", true},
+		{"**/*.json", "c.json", true},
+		{"data/**", "data/x/y.csv", true},
+		{"img_?.png", "img_1.png", true},
+		{"img_[0-9].png", "img_a.png", false},
+		{"img_[!0-9].png", "img_a.png", true},
+	}
+	for _, c := range cases {
+		glob, err := compileGlob(c.pattern)
+		if err != nil {
+			t.Fatalf("compileGlob(%q): %v", c.pattern, err)
+		}
+		if got := glob.MatchString(c.path); got != c.want {
+			t.Errorf("glob %q matching %q = %v, want %v", c.pattern, c.path, got, c.want)
+		}
+	}
+}
+
+// fileSetTestMetadata returns metadata with an images FileSet contained in a directory FileObject.
+func fileSetTestMetadata() Metadata {
+	return Metadata{
+		Type: "sc:Dataset",
+		Name: "images",
+		Distributions: []Distribution{
+			{
+				ID:             "images-dir",
+				Type:           "cr:FileObject",
+				Name:           "images-dir",
+				ContentURL:     "images",
+				EncodingFormat: "application/x-directory",
+			},
+			{
+				ID:             "image-files",
+				Type:           "cr:FileSet",
+				Name:           "image-files",
+				ContainedIn:    &FileObjectRef{ID: "images-dir"},
+				EncodingFormat: "image/jpeg",
+				Includes:       "*.jpg",
+				Excludes:       "**/skip_*",
+			},
+		},
+		RecordSets: []RecordSet{
+			{
+				ID:   "images",
+				Type: "cr:RecordSet",
+				Name: "images",
+				Fields: []Field{
+					{
+						ID:       "images/filename",
+						Type:     "cr:Field",
+						Name:     "filename",
+						DataType: NewSingleDataType(VT_scText),
+						Source: FieldSource{
+							FileSet: FileObject{ID: "image-files"},
+							Extract: Extract{FileProperty: "filename"},
+						},
+					},
+					{
+						ID:       "images/split",
+						Type:     "cr:Field",
+						Name:     "split",
+						DataType: NewSingleDataType(VT_scText),
+						Source: FieldSource{
+							FileSet:   FileObject{ID: "image-files"},
+							Extract:   Extract{FileProperty: "fullpath"},
+							Transform: TransformSlice{{Regex: `^(train|val)/`}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveFileSet(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "images/train/a.jpg", "a")
+	writeTestFile(t, dir, "images/train/skip_b.jpg", "b")
+	writeTestFile(t, dir, "images/val/c.jpg", "c")
+	writeTestFile(t, dir, "images/val/notes.txt", "not an image")
+
+	files, err := ResolveFileSet(fileSetTestMetadata(), "image-files", dir)
+	if err != nil {
+		t.Fatalf("ResolveFileSet: %v", err)
+	}
+
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	slices.Sort(paths)
+	if want := []string{"train/a.jpg", "val/c.jpg"}; !slices.Equal(paths, want) {
+		t.Errorf("resolved %v, want %v", paths, want)
+	}
+}
+
+func TestResolveFileSetWithoutBaseDir(t *testing.T) {
+	metadata := fileSetTestMetadata()
+	metadata.Distributions[1].ContainedIn = nil
+
+	if _, err := ResolveFileSet(metadata, "image-files", ""); err == nil {
+		t.Error("ResolveFileSet of a FileSet without containedIn or base directory should fail")
+	}
+}
+
+func TestRecordsFileSet(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "images/train/a.jpg", "a")
+	writeTestFile(t, dir, "images/val/c.jpg", "c")
+
+	splits := make(map[string]interface{})
+	for record, err := range RecordsWithOptions(context.Background(), fileSetTestMetadata(), "images", RecordOptions{BaseDir: dir}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		splits[record["images/filename"].(string)] = record["images/split"]
+	}
+
+	if splits["a.jpg"] != "train" || splits["c.jpg"] != "val" || len(splits) != 2 {
+		t.Errorf("unexpected records: %v", splits)
+	}
+}
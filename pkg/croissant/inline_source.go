@@ -0,0 +1,81 @@
+// inline_source.go
+// Reads record values from the inline data of a RecordSet.
+package croissant
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// inlineSource yields the rows embedded in a record set's data property,
+// such as enumerations and splits.
+//
+// Each row maps field IDs (or field names) to JSON values. Numbers are
+// returned as json.Number, like values read from JSON distributions, so that
+// they are converted according to the field's DataType.
+type inlineSource struct {
+	rows []map[string]interface{}
+	keys map[string][]string // field key -> keys looked up in each row
+	row  int
+}
+
+// newInlineSource maps each field to the keys identifying it in the inline rows.
+func newInlineSource(recordSet *RecordSet, fields []Field) *inlineSource {
+	source := &inlineSource{
+		rows: recordSet.Data,
+		keys: make(map[string][]string, len(fields)),
+	}
+	for _, field := range fields {
+		keys := []string{FieldKey(field)}
+		if field.Name != "" && field.Name != FieldKey(field) {
+			keys = append(keys, field.Name)
+		}
+		source.keys[FieldKey(field)] = keys
+	}
+
+	return source
+}
+
+// Next returns the values of the next inline row.
+func (s *inlineSource) Next() (map[string]interface{}, error) {
+	if s.row >= len(s.rows) {
+		return nil, io.EOF
+	}
+
+	row := s.rows[s.row]
+	values := make(map[string]interface{}, len(s.keys))
+	for field, keys := range s.keys {
+		for _, key := range keys {
+			if value, exists := row[key]; exists {
+				values[field] = inlineValue(value)
+				break
+			}
+		}
+	}
+	s.row++
+
+	return values, nil
+}
+
+// Close is a no-op; inline rows are part of the metadata.
+func (s *inlineSource) Close() error {
+	return nil
+}
+
+// inlineValue normalizes a value decoded from inline data, turning float64
+// numbers into json.Number so that integers keep their integer type.
+func inlineValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case float64:
+		return json.Number(strconv.FormatFloat(typed, 'f', -1, 64))
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = inlineValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
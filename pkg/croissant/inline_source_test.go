@@ -0,0 +1,41 @@
+// File: pkg/croissant/inline_source_test.go
+package croissant
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRecordsInlineData(t *testing.T) {
+	var metadata Metadata
+	data := `{
+		"@type": "sc:Dataset",
+		"name": "inline",
+		"recordSet": [{
+			"@id": "labels",
+			"@type": "cr:RecordSet",
+			"name": "labels",
+			"field": [
+				{"@id": "labels/id", "@type": "cr:Field", "name": "id", "dataType": "sc:Integer"},
+				{"@id": "labels/name", "@type": "cr:Field", "name": "name", "dataType": "sc:Text"}
+			],
+			"data": [
+				{"labels/id": 0, "labels/name": "cat"},
+				{"id": 1, "name": "dog"}
+			]
+		}]
+	}`
+	if err := json.Unmarshal([]byte(data), &metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readTestRecords(t, metadata, "labels", "")
+	want := []Record{
+		{"labels/id": int64(0), "labels/name": "cat"},
+		{"labels/id": int64(1), "labels/name": "dog"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
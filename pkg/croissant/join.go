@@ -0,0 +1,160 @@
+// join.go
+// Joins record sets through fields that reference fields of other record sets.
+package croissant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// recordJoin attaches the record referenced by a foreign key to each record.
+//
+// All fields of a record set that reference fields of the same record set form a
+// single (possibly composite) foreign key. The referenced record is nested under
+// the key of the referenced record set.
+type recordJoin struct {
+	// Key of the referenced record set, under which matched records are nested.
+	recordSet string
+	// Keys of the referencing fields, in the order of the referenced fields.
+	fields []string
+	// Keys of the referenced fields.
+	targets []string
+	// Referenced records by joinKey of their target values.
+	index map[string]Record
+}
+
+// newRecordJoins groups the references of a record set's fields by referenced record set.
+// Record sets already being read (in visiting) are not joined again, which stops cycles.
+func newRecordJoins(metadata Metadata, recordSet *RecordSet, visiting map[string]bool) ([]*recordJoin, error) {
+	var joins []*recordJoin
+	joinsByRecordSet := make(map[string]*recordJoin)
+
+	for _, field := range recordSet.Fields {
+		for _, ref := range fieldReferences(field) {
+			targetSet, targetField := FindField(metadata, ref.FieldID())
+			if targetField == nil {
+				return nil, CroissantError{
+					Message: fmt.Sprintf("field %q references a non-existent field", FieldKey(field)),
+					Value:   ref.FieldID(),
+				}
+			}
+
+			targetKey := recordSetKey(*targetSet)
+			if visiting[targetKey] {
+				continue
+			}
+
+			join, exists := joinsByRecordSet[targetKey]
+			if !exists {
+				join = &recordJoin{recordSet: targetKey}
+				joinsByRecordSet[targetKey] = join
+				joins = append(joins, join)
+			}
+			join.fields = append(join.fields, FieldKey(field))
+			join.targets = append(join.targets, FieldKey(*targetField))
+		}
+	}
+
+	return joins, nil
+}
+
+// fieldReferences returns the fields a field references, through references or parentField.
+func fieldReferences(field Field) []FieldRef {
+	refs := make([]FieldRef, 0, len(field.References)+len(field.ParentField))
+	for _, ref := range append(append([]FieldRef{}, field.References...), field.ParentField...) {
+		if ref.FieldID() != "" {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+// load reads every record of the referenced record set and indexes them by their target values.
+// Records with a null target value cannot be referenced and are skipped; the first record
+// wins when several share the same target values.
+func (j *recordJoin) load(ctx context.Context, metadata Metadata, options RecordOptions, visiting map[string]bool) error {
+	reader, err := openRecordReader(ctx, metadata, j.recordSet, options, visiting)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	j.index = make(map[string]Record)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Row-level errors are reported when the referenced record set itself is read.
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		key, ok := joinKey(record, j.targets)
+		if !ok {
+			continue
+		}
+		if _, exists := j.index[key]; !exists {
+			j.index[key] = record
+		}
+	}
+}
+
+// apply nests the referenced record in a record.
+// Returns a *RecordError if the record's foreign key matches no referenced record.
+func (j *recordJoin) apply(record Record, recordSet string, row int) *RecordError {
+	key, ok := joinKey(record, j.fields)
+	if !ok {
+		// Null foreign keys reference nothing.
+		return nil
+	}
+
+	referenced, exists := j.index[key]
+	if !exists {
+		values := make([]interface{}, len(j.fields))
+		for i, field := range j.fields {
+			values[i] = record[field]
+		}
+		var value interface{} = values
+		if len(values) == 1 {
+			value = values[0]
+		}
+		return &RecordError{
+			RecordSet: recordSet,
+			Row:       row,
+			Field:     j.fields[0],
+			Value:     value,
+			Err: CroissantError{
+				Message: fmt.Sprintf("unmatched reference to %s", strings.Join(j.targets, ", ")),
+				Value:   value,
+			},
+		}
+	}
+	record[j.recordSet] = referenced
+
+	return nil
+}
+
+// joinKey combines the values of some fields of a record into a lookup key.
+// Values are compared by their text form, so that e.g. the integer 1 matches the text "1".
+// Returns false if any of the values is null.
+func joinKey(record Record, fields []string) (string, bool) {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		value, exists := record[field]
+		if !exists || value == nil {
+			return "", false
+		}
+		parts[i] = stringifyValue(value)
+	}
+
+	return strings.Join(parts, "\x00"), true
+}
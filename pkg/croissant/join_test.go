@@ -0,0 +1,127 @@
+// File: pkg/croissant/join_test.go
+package croissant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// joinTestMetadata returns metadata where annotations reference images through image_id.
+func joinTestMetadata() Metadata {
+	field := func(recordSet string, column string, dataType string, distribution string) Field {
+		return Field{
+			ID:       recordSet + "/" + column,
+			Type:     "cr:Field",
+			Name:     column,
+			DataType: NewSingleDataType(dataType),
+			Source: FieldSource{
+				FileObject: FileObject{ID: distribution},
+				Extract:    Extract{Column: column},
+			},
+		}
+	}
+
+	imageID := field("annotations", "image_id", VT_scInt, "annotations.csv")
+	imageID.References = FieldRefSlice{{ID: "images/id"}}
+
+	return Metadata{
+		Type: "sc:Dataset",
+		Name: "join",
+		Distributions: []Distribution{
+			{ID: "images.csv", Type: "cr:FileObject", Name: "images.csv", ContentURL: "images.csv", EncodingFormat: "text/csv"},
+			{ID: "annotations.csv", Type: "cr:FileObject", Name: "annotations.csv", ContentURL: "annotations.csv", EncodingFormat: "text/csv"},
+		},
+		RecordSets: []RecordSet{
+			{
+				ID:   "images",
+				Type: "cr:RecordSet",
+				Name: "images",
+				Fields: []Field{
+					field("images", "id", VT_scInt, "images.csv"),
+					field("images", "file_name", VT_scText, "images.csv"),
+				},
+			},
+			{
+				ID:   "annotations",
+				Type: "cr:RecordSet",
+				Name: "annotations",
+				Fields: []Field{
+					field("annotations", "label", VT_scText, "annotations.csv"),
+					imageID,
+				},
+			},
+		},
+	}
+}
+
+func TestRecordsJoin(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "images.csv", "id,file_name\n1,a.jpg\n2,b.jpg\n")
+	writeTestFile(t, dir, "annotations.csv", "label,image_id\ncat,2\ndog,\nbird,3\n")
+
+	var files []interface{}
+	var unmatched []*RecordError
+	for record, err := range RecordsWithOptions(context.Background(), joinTestMetadata(), "annotations", RecordOptions{BaseDir: dir}) {
+		var recordErr *RecordError
+		if errors.As(err, &recordErr) {
+			unmatched = append(unmatched, recordErr)
+		} else if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if image, ok := record["images"].(Record); ok {
+			files = append(files, image["images/file_name"])
+		} else {
+			files = append(files, nil)
+		}
+	}
+
+	if len(files) != 3 || files[0] != "b.jpg" || files[1] != nil || files[2] != nil {
+		t.Errorf("joined file names = %v, want [b.jpg <nil> <nil>]", files)
+	}
+	if len(unmatched) != 1 || unmatched[0].Row != 3 || unmatched[0].Field != "annotations/image_id" || unmatched[0].Value != int64(3) {
+		t.Errorf("unmatched references = %v, want row 3 with image_id 3", unmatched)
+	}
+}
+
+func TestFieldRefSliceJSON(t *testing.T) {
+	cases := []string{
+		`{"@id": "images/id"}`,
+		`{"field": {"@id": "images/id"}}`,
+		`[{"field": {"@id": "images/id"}}]`,
+	}
+	for _, data := range cases {
+		var refs FieldRefSlice
+		if err := json.Unmarshal([]byte(data), &refs); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if len(refs) != 1 || refs[0].FieldID() != "images/id" {
+			t.Errorf("Unmarshal(%s) = %+v, want a reference to images/id", data, refs)
+		}
+	}
+}
+
+func TestRecordsJoinInlineData(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id,split\n1,train\n2,test\n")
+
+	split := csvTestField("split", VT_scText)
+	split.References = FieldRefSlice{{ID: "splits/name"}}
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt), split)
+	metadata.RecordSets = append(metadata.RecordSets, CreateSplitRecordSet())
+
+	records := readTestRecords(t, metadata, "main", dir)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	wantURLs := []string{"cr:TrainingSplit", "cr:TestSplit"}
+	for i, record := range records {
+		splits, ok := record["splits"].(Record)
+		if !ok || fmt.Sprint(splits["splits/url"]) != wantURLs[i] {
+			t.Errorf("record %d: splits = %v, want url %s", i, record["splits"], wantURLs[i])
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// json_source.go
+// Reads record values from JSON and JSON Lines distributions.
+package croissant
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonFieldPaths compiles the jsonPath extracted by each field of a JSON distribution.
+func jsonFieldPaths(dist Distribution, fields []Field) (map[string]*jsonPath, error) {
+	paths := make(map[string]*jsonPath, len(fields))
+	for _, field := range fields {
+		expr := field.Source.Extract.JSONPath
+		if expr == "" {
+			return nil, CroissantError{
+				Message: fmt.Sprintf("field %q must extract a jsonPath from JSON distribution %q", FieldKey(field), dist.ID),
+			}
+		}
+		path, err := compileJSONPath(expr)
+		if err != nil {
+			return nil, err
+		}
+		paths[FieldKey(field)] = path
+	}
+
+	return paths, nil
+}
+
+// decodeJSON decodes a JSON value, keeping numbers as json.Number so that
+// integers are not rounded through float64.
+func decodeJSON(data io.Reader) (interface{}, error) {
+	decoder := json.NewDecoder(data)
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// jsonSource yields rows extracted from a single JSON document.
+//
+// Each field's jsonPath is evaluated against the whole document. Paths that
+// can match several values (e.g. "$.annotations[*].id") produce one row per
+// match, and must all match the same number of values. Definite paths
+// (e.g. "$.info.version") produce a single value repeated on every row.
+type jsonSource struct {
+	columns map[string][]interface{}
+	scalars map[string]interface{}
+	rows    int
+	row     int
+}
+
+// openJSONSource reads a JSON distribution and evaluates the jsonPath of every field.
+func openJSONSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions) (*jsonSource, error) {
+	paths, err := jsonFieldPaths(dist, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openDistributionContent(ctx, metadata, dist, options.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	document, err := decodeJSON(file)
+	if err != nil {
+		return nil, CroissantError{Message: fmt.Sprintf("failed to parse JSON distribution %q", dist.ID), Value: err}
+	}
+
+	source := &jsonSource{
+		columns: make(map[string][]interface{}),
+		scalars: make(map[string]interface{}),
+		rows:    -1,
+	}
+	for key, path := range paths {
+		if path.Definite() {
+			source.scalars[key] = path.Extract(document)
+			continue
+		}
+
+		column := path.Evaluate(document)
+		if source.rows >= 0 && len(column) != source.rows {
+			return nil, CroissantError{
+				Message: fmt.Sprintf("jsonPath of field %q matches %d values, expected %d", key, len(column), source.rows),
+				Value:   path.expr,
+			}
+		}
+		source.rows = len(column)
+		source.columns[key] = column
+	}
+	if source.rows < 0 {
+		source.rows = 1
+	}
+
+	return source, nil
+}
+
+// Next returns the values of the next row.
+func (s *jsonSource) Next() (map[string]interface{}, error) {
+	if s.row >= s.rows {
+		return nil, io.EOF
+	}
+
+	values := make(map[string]interface{}, len(s.columns)+len(s.scalars))
+	for key, column := range s.columns {
+		values[key] = column[s.row]
+	}
+	for key, value := range s.scalars {
+		values[key] = value
+	}
+	s.row++
+
+	return values, nil
+}
+
+// Close releases the parsed document.
+func (s *jsonSource) Close() error {
+	s.columns = nil
+	s.scalars = nil
+	return nil
+}
+
+// jsonLinesSource yields one row per line of a JSON Lines distribution.
+//
+// Each field's jsonPath is evaluated against the line's value. Paths that can
+// match several values produce a slice of every match, as do definite paths
+// pointing at an array, which is how repeated fields are populated.
+type jsonLinesSource struct {
+	file   io.ReadCloser
+	reader *bufio.Reader
+	paths  map[string]*jsonPath
+	line   int
+}
+
+// openJSONLinesSource opens a JSON Lines distribution.
+func openJSONLinesSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions) (*jsonLinesSource, error) {
+	paths, err := jsonFieldPaths(dist, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openDistributionContent(ctx, metadata, dist, options.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonLinesSource{
+		file:   file,
+		reader: bufio.NewReader(file),
+		paths:  paths,
+	}, nil
+}
+
+// Next returns the values of the next non-empty line.
+func (s *jsonLinesSource) Next() (map[string]interface{}, error) {
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, CroissantError{Message: "failed to read JSON Lines", Value: err}
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			if err != nil {
+				return nil, io.EOF
+			}
+			s.line++
+			continue
+		}
+		s.line++
+
+		value, decodeErr := decodeJSON(bytes.NewReader(line))
+		if decodeErr != nil {
+			return nil, CroissantError{Message: fmt.Sprintf("failed to parse JSON on line %d", s.line), Value: decodeErr}
+		}
+
+		values := make(map[string]interface{}, len(s.paths))
+		for key, path := range s.paths {
+			values[key] = path.Extract(value)
+		}
+
+		return values, nil
+	}
+}
+
+// Close closes the underlying file.
+func (s *jsonLinesSource) Close() error {
+	return s.file.Close()
+}
@@ -2,7 +2,11 @@
 package croissant
 
 import (
+	"bytes"
 	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
@@ -14,11 +18,39 @@ type JSONLDProcessor struct {
 	options   *ld.JsonLdOptions
 }
 
+// JSONLDOptions represents options for JSON-LD processing.
+type JSONLDOptions struct {
+	// Never fetch remote documents. Only the embedded Croissant contexts and the
+	// documents added to DocumentLoader can be referenced by URL.
+	Offline bool
+	// Loader for the contexts documents refer to by URL.
+	// Defaults to a DocumentLoader with the embedded Croissant contexts.
+	DocumentLoader ld.DocumentLoader
+}
+
+// DefaultJSONLDOptions returns default JSON-LD processing options.
+func DefaultJSONLDOptions() JSONLDOptions {
+	return JSONLDOptions{
+		Offline: false, // Fetch contexts that are not embedded
+	}
+}
+
 // NewJSONLDProcessor creates a new JSON-LD processor.
 func NewJSONLDProcessor() *JSONLDProcessor {
+	return NewJSONLDProcessorWithOptions(DefaultJSONLDOptions())
+}
+
+// NewJSONLDProcessorWithOptions creates a new JSON-LD processor with specific options.
+func NewJSONLDProcessorWithOptions(options JSONLDOptions) *JSONLDProcessor {
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.DocumentLoader = options.DocumentLoader
+	if ldOptions.DocumentLoader == nil {
+		ldOptions.DocumentLoader = NewDocumentLoader(options.Offline)
+	}
+
 	return &JSONLDProcessor{
 		processor: ld.NewJsonLdProcessor(),
-		options:   ld.NewJsonLdOptions(""),
+		options:   ldOptions,
 	}
 }
 
@@ -77,22 +109,277 @@ func (j *JSONLDProcessor) ValidateJSONLD(data []byte) error {
 }
 
 // ParseCroissantMetadata parses Croissant JSON-LD metadata and converts it to our Metadata struct.
+//
+// The document is expanded, then compacted against the canonical Croissant context before
+// being decoded, so that documents using other prefixes, full IRIs (e.g. "https://schema.org/name")
+// or aliased terms yield the same Metadata. Terms of the legacy "ml" namespace
+// (http://mlcommons.org/schema/) are read as Croissant ones. Metadata.Context holds the document's own context
+// if it is an object, and the default context otherwise.
 func (j *JSONLDProcessor) ParseCroissantMetadata(data []byte) (*Metadata, error) {
-	// First validate that it's valid JSON-LD
-	if err := j.ValidateJSONLD(data); err != nil {
+	// Numbers are decoded as json.Number, so that large integers keep their exact value.
+	var jsonDoc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonDoc); err != nil {
+		return nil, CroissantError{Message: "invalid JSON", Value: err}
+	}
+
+	expanded, err := j.processor.Expand(withoutEmptyTermDefinitions(jsonDoc), j.options)
+	if err != nil {
+		return nil, CroissantError{Message: "invalid JSON-LD", Value: err}
+	}
+	if len(expanded) != 1 {
+		return nil, CroissantError{Message: "expected a single Croissant dataset", Value: len(expanded)}
+	}
+
+	canonical, err := loadEmbeddedContext(canonicalContextURL, embeddedContextFiles[canonicalContextURL])
+	if err != nil {
 		return nil, err
 	}
+	contextMap, ok := canonical.Document.(map[string]interface{})
+	if !ok {
+		return nil, CroissantError{Message: "unexpected canonical context structure"}
+	}
+	compaction := &compaction{croissantTerms: croissantVocabularyTerms(contextMap)}
+	compacted, err := j.CompactJSONLD(compaction.prepare(expanded[0]), contextMap)
+	if err != nil {
+		return nil, err
+	}
+	if _, isGraph := compacted["@graph"]; isGraph {
+		return nil, CroissantError{Message: "expected a single Croissant dataset", Value: "@graph"}
+	}
 
-	// Parse as regular JSON into our Metadata struct
-	// The json-gold validation ensures it's properly structured JSON-LD
+	delete(compacted, "@context")
+	compaction.normalize(compacted, "")
+	decoded, err := json.Marshal(compacted)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to parse Croissant metadata", Value: err}
+	}
 	var metadata Metadata
-	if err := json.Unmarshal(data, &metadata); err != nil {
+	if err := json.Unmarshal(decoded, &metadata); err != nil {
 		return nil, CroissantError{Message: "failed to parse Croissant metadata", Value: err}
 	}
 
+	metadata.Context = CreateDefaultContext()
+	if documentContext, isObject := jsonDoc["@context"].(map[string]interface{}); isObject {
+		metadata.Context = Context{}
+		contextJSON, _ := json.Marshal(documentContext)
+		if err := json.Unmarshal(contextJSON, &metadata.Context); err != nil {
+			return nil, CroissantError{Message: "failed to parse Croissant context", Value: err}
+		}
+	}
+	// Types and IRIs are compacted with the "sc" and "cr" prefixes of the canonical context.
+	if metadata.Context.SC == "" {
+		metadata.Context.SC = schemaOrgIRI
+	}
+	if metadata.Context.CR == "" {
+		metadata.Context.CR = croissantIRI
+	}
+	// RAI properties are compacted with the "rai" prefix of the canonical context.
+	if !metadata.RAI.IsEmpty() && !declaresRAIPrefix(metadata.Context) {
+		metadata.Context.RAI = RAIPrefix
+	}
+
 	return &metadata, nil
 }
 
+// withoutEmptyTermDefinitions returns a document whose object context has no terms defined as ""
+// or as {"@id": ""}, such as the unset terms of an encoded Context. JSON-LD would otherwise
+// map these terms to the vocabulary IRI itself.
+func withoutEmptyTermDefinitions(document map[string]interface{}) map[string]interface{} {
+	context, isObject := document["@context"].(map[string]interface{})
+	if !isObject {
+		return document
+	}
+
+	cleaned := make(map[string]interface{}, len(context))
+	for term, definition := range context {
+		if object, isObject := definition.(map[string]interface{}); isObject {
+			definition = object["@id"]
+		}
+		if definition != "" {
+			cleaned[term] = context[term]
+		}
+	}
+	copied := maps.Clone(document)
+	copied["@context"] = cleaned
+
+	return copied
+}
+
+// canonicalContextURL is the URL of the context documents are compacted against when parsed.
+const canonicalContextURL = "http://mlcommons.org/croissant/1.1"
+
+const (
+	schemaOrgIRI  = "https://schema.org/"
+	croissantIRI  = "http://mlcommons.org/croissant/"
+	dublinCoreIRI = "http://purl.org/dc/terms/"
+	// Namespace of the Croissant vocabulary before 1.0, used with the "ml" prefix.
+	legacyCroissantIRI = "http://mlcommons.org/schema/"
+)
+
+// croissantVocabularyTerms returns the IRIs of the terms of a context that are Croissant or
+// Dublin Core properties, such as "recordSet" for "cr:recordSet" or "conformsTo" for "dct:conformsTo".
+func croissantVocabularyTerms(contextDocument map[string]interface{}) map[string]string {
+	context, _ := contextDocument["@context"].(map[string]interface{})
+	terms := make(map[string]string)
+	for term, definition := range context {
+		if object, isObject := definition.(map[string]interface{}); isObject {
+			definition = object["@id"]
+		}
+		switch definition {
+		case "cr:" + term:
+			terms[term] = croissantIRI + term
+		case "dct:" + term:
+			terms[term] = dublinCoreIRI + term
+		}
+	}
+
+	return terms
+}
+
+// compaction prepares an expanded document for compaction, and the compacted document for decoding.
+type compaction struct {
+	// IRIs of the terms of the canonical context that are Croissant or Dublin Core properties.
+	croissantTerms map[string]string
+	// Values of the @json literals, replaced by jsonLiteralMarker and their index during compaction,
+	// as json-gold compaction flattens literals that are arrays.
+	literals []interface{}
+}
+
+// jsonLiteralMarker prefixes the placeholders of @json literals.
+const jsonLiteralMarker = "\x00json-literal:"
+
+// prepare reads Croissant properties that a document's context does not define, and were
+// therefore expanded under the schema.org vocabulary, as their Croissant or Dublin Core property.
+// Properties, types and IRIs of the legacy "ml" namespace are read as Croissant ones.
+// @json literals are replaced by a placeholder.
+func (c *compaction) prepare(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = c.prepare(item)
+		}
+	case map[string]interface{}:
+		if typed["@type"] == "@json" {
+			c.literals = append(c.literals, typed["@value"])
+			typed["@value"] = jsonLiteralMarker + strconv.Itoa(len(c.literals)-1)
+			return typed
+		}
+		for _, key := range slices.Collect(maps.Keys(typed)) {
+			item := c.prepare(typed[key])
+			typed[key] = item
+			if key == "@type" || key == "@id" {
+				typed[key] = fromLegacyNamespace(item)
+				continue
+			}
+			term, isSchemaOrg := strings.CutPrefix(key, schemaOrgIRI)
+			legacyTerm, isLegacy := strings.CutPrefix(key, legacyCroissantIRI)
+			var property string
+			switch {
+			case isSchemaOrg && c.croissantTerms[term] != "":
+				property = c.croissantTerms[term]
+			case isLegacy:
+				property = croissantIRI + legacyTerm
+			default:
+				continue
+			}
+			delete(typed, key)
+			items, _ := item.([]interface{})
+			existing, _ := typed[property].([]interface{})
+			typed[property] = append(existing, items...)
+		}
+	}
+
+	return value
+}
+
+// fromLegacyNamespace replaces the legacy "ml" namespace of IRIs, such as the types of an
+// expanded object, with the Croissant namespace.
+func fromLegacyNamespace(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case string:
+		if term, isLegacy := strings.CutPrefix(typed, legacyCroissantIRI); isLegacy {
+			return croissantIRI + term
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = fromLegacyNamespace(item)
+		}
+	}
+
+	return value
+}
+
+// compactedListProperties are the properties decoded as slices, by the property that holds them.
+// Compaction turns single-element lists into single values.
+var compactedListProperties = map[string][]string{ //nolint:gochecknoglobals
+	"":          {"distribution", "recordSet", "keywords"},
+	"recordSet": {"field"},
+	"field":     {"subField"},
+	"subField":  {"subField"},
+}
+
+// normalize prepares a compacted object for decoding: @json literals are restored, value objects
+// are replaced by their value, types in the schema.org vocabulary get their "sc:" prefix back,
+// and list properties are made lists. parent is the property holding the object, "" for the dataset.
+func (c *compaction) normalize(object map[string]interface{}, parent string) {
+	for key, value := range object {
+		if key == "@type" || key == "dataType" {
+			object[key] = prefixSchemaOrgTypes(value)
+			continue
+		}
+		object[key] = c.normalizeValue(value, key)
+	}
+
+	for _, key := range compactedListProperties[parent] {
+		if value, exists := object[key]; exists {
+			if _, isList := value.([]interface{}); !isList {
+				object[key] = []interface{}{value}
+			}
+		}
+	}
+}
+
+// normalizeValue normalizes the value of a property of a compacted object.
+func (c *compaction) normalizeValue(value interface{}, property string) interface{} {
+	switch typed := value.(type) {
+	case string:
+		if index, isLiteral := strings.CutPrefix(typed, jsonLiteralMarker); isLiteral {
+			if i, err := strconv.Atoi(index); err == nil && i < len(c.literals) {
+				return c.literals[i]
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = c.normalizeValue(item, property)
+		}
+	case map[string]interface{}:
+		if literal, isValue := typed["@value"]; isValue {
+			return c.normalizeValue(literal, property)
+		}
+		c.normalize(typed, property)
+	}
+
+	return value
+}
+
+// prefixSchemaOrgTypes adds the "sc:" prefix to types compacted to a term of the schema.org vocabulary.
+func prefixSchemaOrgTypes(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case string:
+		if typed != "" && !strings.Contains(typed, ":") && !strings.HasPrefix(typed, "@") {
+			return "sc:" + typed
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = prefixSchemaOrgTypes(item)
+		}
+	}
+
+	return value
+}
+
 // GetExpandedProperty retrieves a property from expanded JSON-LD using its full IRI.
 func GetExpandedProperty(expanded map[string]interface{}, property string) interface{} {
 	// Try direct property access first
@@ -0,0 +1,104 @@
+// File: pkg/croissant/jsonld_test.go
+package croissant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCroissantMetadataEquivalentDocuments(t *testing.T) {
+	documents := map[string]string{
+		"compact": `{
+			"@context": {"@vocab": "https://schema.org/", "sc": "https://schema.org/", "cr": "http://mlcommons.org/croissant/",
+				"recordSet": "cr:recordSet", "field": "cr:field", "dataType": {"@id": "cr:dataType", "@type": "@vocab"},
+				"source": "cr:source", "fileObject": "cr:fileObject", "extract": "cr:extract", "column": "cr:column"},
+			"@type": "sc:Dataset",
+			"name": "cities",
+			"distribution": {"@type": "cr:FileObject", "@id": "cities.csv", "name": "cities.csv", "contentUrl": "cities.csv"},
+			"recordSet": [{"@type": "cr:RecordSet", "@id": "cities", "name": "cities",
+				"field": {"@type": "cr:Field", "@id": "cities/name", "name": "name", "dataType": "sc:Text",
+					"source": {"fileObject": {"@id": "cities.csv"}, "extract": {"column": "name"}}}}]
+		}`,
+		"full IRIs": `{
+			"@type": "https://schema.org/Dataset",
+			"https://schema.org/name": "cities",
+			"https://schema.org/distribution": [{"@type": "http://mlcommons.org/croissant/FileObject", "@id": "cities.csv",
+				"https://schema.org/name": "cities.csv", "https://schema.org/contentUrl": "cities.csv"}],
+			"http://mlcommons.org/croissant/recordSet": {"@type": "http://mlcommons.org/croissant/RecordSet", "@id": "cities",
+				"https://schema.org/name": "cities",
+				"http://mlcommons.org/croissant/field": {"@type": "http://mlcommons.org/croissant/Field", "@id": "cities/name",
+					"https://schema.org/name": "name",
+					"http://mlcommons.org/croissant/dataType": {"@id": "https://schema.org/Text"},
+					"http://mlcommons.org/croissant/source": {
+						"http://mlcommons.org/croissant/fileObject": {"@id": "cities.csv"},
+						"http://mlcommons.org/croissant/extract": {"http://mlcommons.org/croissant/column": "name"}}}}
+		}`,
+		"other prefixes and aliases": `{
+			"@context": ["http://mlcommons.org/croissant/1.0", {"schema": "https://schema.org/", "ml": "http://mlcommons.org/croissant/",
+				"title": "schema:name", "records": "ml:recordSet", "files": "schema:distribution"}],
+			"@type": "schema:Dataset",
+			"title": "cities",
+			"files": [{"@type": "ml:FileObject", "@id": "cities.csv", "title": "cities.csv", "contentUrl": "cities.csv"}],
+			"records": [{"@type": "ml:RecordSet", "@id": "cities", "title": "cities",
+				"field": [{"@type": "ml:Field", "@id": "cities/name", "title": "name", "dataType": "schema:Text",
+					"source": {"fileObject": {"@id": "cities.csv"}, "extract": {"column": "name"}}}]}]
+		}`,
+	}
+
+	processor := NewJSONLDProcessorWithOptions(JSONLDOptions{Offline: true})
+	var want *Metadata
+	for _, name := range []string{"compact", "full IRIs", "other prefixes and aliases"} {
+		metadata, err := processor.ParseCroissantMetadata([]byte(documents[name]))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		metadata.Context = Context{}
+		if want == nil {
+			want = metadata
+			if len(want.RecordSets) != 1 || len(want.RecordSets[0].Fields) != 1 || want.RecordSets[0].Fields[0].DataType.GetFirstType() != VT_scText {
+				t.Fatalf("%s: unexpected record sets %+v", name, want.RecordSets)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(metadata, want) {
+			t.Errorf("%s parses to %+v, want %+v", name, metadata, want)
+		}
+	}
+}
+
+func TestValidateNonCanonicalNamespace(t *testing.T) {
+	document := `{
+		"@context": {"@vocab": "https://schema.org/", "sc": "https://schema.org/", "cr": "http://mlcommons.org/croissant/",
+			"recordSet": "https://example.com/croissant/recordSet"},
+		"@type": "sc:Dataset",
+		"name": "cities",
+		"recordSet": [{"@type": "cr:RecordSet", "name": "cities"}]
+	}`
+	issues, err := ValidateJSON([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := issues.Report()
+	want := `Property "https://example.com/croissant/recordSet" is not part of the Croissant vocabulary, and is ignored. Did you mean "recordSet"?`
+	if !strings.Contains(report, want) {
+		t.Errorf("report is missing %q:\n%s", want, report)
+	}
+}
+
+func TestParseCroissantMetadataLegacyNamespace(t *testing.T) {
+	metadata, err := LoadMetadataFromFile("testdata/1.0/good/simple_parquet.jsonld")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metadata.RecordSets) != 1 || len(metadata.RecordSets[0].Fields) != 2 {
+		t.Fatalf("record sets = %+v, want persons with 2 fields", metadata.RecordSets)
+	}
+	if recordSet := metadata.RecordSets[0]; recordSet.Type != "cr:RecordSet" || recordSet.Fields[0].Type != "cr:Field" {
+		t.Errorf("legacy ml types were not read as Croissant types: %s, %s", recordSet.Type, recordSet.Fields[0].Type)
+	}
+	if len(metadata.Extra) != 0 {
+		t.Errorf("Extra = %v, want no unmodelled properties", metadata.Extra)
+	}
+}
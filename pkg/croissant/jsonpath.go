@@ -0,0 +1,688 @@
+// jsonpath.go
+// Evaluates JSONPath expressions against decoded JSON values.
+package croissant
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled JSONPath expression.
+//
+// Supported syntax: the root "$" (optional), child names ".name" and
+// "['name']", indices "[0]" and "[-1]", unions "[0,2]" and "['a','b']",
+// slices "[start:end:step]", wildcards ".*" and "[*]", recursive descent
+// "..name", and filters such as "[?(@.label == 'cat' && @.score > 0.5)]".
+type jsonPath struct {
+	expr     string
+	segments []jsonPathSegment
+}
+
+// jsonPathSegment is one step of a path, optionally applied to every descendant.
+type jsonPathSegment struct {
+	recursive bool
+	selector  jsonPathSelector
+}
+
+// jsonPathSelector selects children of a JSON value.
+type jsonPathSelector interface {
+	selectFrom(value interface{}) []interface{}
+	// definite is true if the selector matches at most one child.
+	definite() bool
+}
+
+// compileJSONPath parses a JSONPath expression.
+func compileJSONPath(expr string) (*jsonPath, error) {
+	parser := &jsonPathParser{expr: strings.TrimSpace(expr)}
+	segments, err := parser.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonPath{expr: expr, segments: segments}, nil
+}
+
+// Definite returns true if the path matches at most one value, i.e. it has no
+// wildcards, unions, slices, filters or recursive descent.
+func (p *jsonPath) Definite() bool {
+	for _, segment := range p.segments {
+		if segment.recursive || !segment.selector.definite() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Evaluate returns every value matched by the path, in document order.
+func (p *jsonPath) Evaluate(document interface{}) []interface{} {
+	nodes := []interface{}{document}
+	for _, segment := range p.segments {
+		var next []interface{}
+		for _, node := range nodes {
+			if segment.recursive {
+				for _, descendant := range jsonDescendants(node) {
+					next = append(next, segment.selector.selectFrom(descendant)...)
+				}
+			} else {
+				next = append(next, segment.selector.selectFrom(node)...)
+			}
+		}
+		nodes = next
+	}
+
+	return nodes
+}
+
+// Extract evaluates the path and returns its value: the single match (or nil)
+// for definite paths, and a slice of every match otherwise.
+func (p *jsonPath) Extract(document interface{}) interface{} {
+	matches := p.Evaluate(document)
+	if !p.Definite() {
+		if matches == nil {
+			return []interface{}{}
+		}
+		return matches
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	return matches[0]
+}
+
+// jsonDescendants returns a value followed by all of its descendants, depth first.
+func jsonDescendants(value interface{}) []interface{} {
+	descendants := []interface{}{value}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(typed) {
+			descendants = append(descendants, jsonDescendants(typed[key])...)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			descendants = append(descendants, jsonDescendants(item)...)
+		}
+	}
+
+	return descendants
+}
+
+// sortedKeys returns the keys of a JSON object in a stable order.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// nameSelector selects object members by name.
+type nameSelector struct {
+	names []string
+}
+
+func (s nameSelector) selectFrom(value interface{}) []interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var results []interface{}
+	for _, name := range s.names {
+		if child, exists := object[name]; exists {
+			results = append(results, child)
+		}
+	}
+
+	return results
+}
+
+func (s nameSelector) definite() bool { return len(s.names) == 1 }
+
+// indexSelector selects array elements by index. Negative indices count from the end.
+type indexSelector struct {
+	indices []int
+}
+
+func (s indexSelector) selectFrom(value interface{}) []interface{} {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var results []interface{}
+	for _, index := range s.indices {
+		if index < 0 {
+			index += len(array)
+		}
+		if index >= 0 && index < len(array) {
+			results = append(results, array[index])
+		}
+	}
+
+	return results
+}
+
+func (s indexSelector) definite() bool { return len(s.indices) == 1 }
+
+// wildcardSelector selects every member of an object or element of an array.
+type wildcardSelector struct{}
+
+func (wildcardSelector) selectFrom(value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		results := make([]interface{}, 0, len(typed))
+		for _, key := range sortedKeys(typed) {
+			results = append(results, typed[key])
+		}
+		return results
+	case []interface{}:
+		return typed
+	default:
+		return nil
+	}
+}
+
+func (wildcardSelector) definite() bool { return false }
+
+// sliceSelector selects a range of array elements, as in Python slices.
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (s sliceSelector) selectFrom(value interface{}) []interface{} {
+	array, ok := value.([]interface{})
+	if !ok || s.step == 0 {
+		return nil
+	}
+
+	length := len(array)
+	bound := func(index *int, fallback int) int {
+		if index == nil {
+			return fallback
+		}
+		i := *index
+		if i < 0 {
+			i += length
+		}
+		return max(-1, min(i, length))
+	}
+
+	var results []interface{}
+	if s.step > 0 {
+		for i := max(bound(s.start, 0), 0); i < bound(s.end, length); i += s.step {
+			results = append(results, array[i])
+		}
+	} else {
+		for i := min(bound(s.start, length-1), length-1); i > bound(s.end, -1); i += s.step {
+			results = append(results, array[i])
+		}
+	}
+
+	return results
+}
+
+func (sliceSelector) definite() bool { return false }
+
+// filterSelector selects the children for which a filter expression holds.
+type filterSelector struct {
+	filter jsonPathFilter
+}
+
+func (s filterSelector) selectFrom(value interface{}) []interface{} {
+	var results []interface{}
+	for _, child := range (wildcardSelector{}).selectFrom(value) {
+		if s.filter.matches(child) {
+			results = append(results, child)
+		}
+	}
+
+	return results
+}
+
+func (filterSelector) definite() bool { return false }
+
+// jsonPathFilter is a filter expression: a disjunction of conjunctions of comparisons.
+type jsonPathFilter struct {
+	disjuncts [][]jsonPathComparison
+}
+
+func (f jsonPathFilter) matches(value interface{}) bool {
+	for _, conjuncts := range f.disjuncts {
+		matched := true
+		for _, comparison := range conjuncts {
+			if !comparison.matches(value) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonPathComparison compares a path relative to the current node with a literal.
+// Without an operator, it tests that the path exists.
+type jsonPathComparison struct {
+	path     *jsonPath
+	operator string
+	literal  interface{}
+}
+
+func (c jsonPathComparison) matches(value interface{}) bool {
+	matches := c.path.Evaluate(value)
+	if c.operator == "" {
+		return len(matches) > 0
+	}
+	for _, match := range matches {
+		if compareJSONValues(match, c.operator, c.literal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareJSONValues applies a comparison operator to two JSON values.
+// Numbers are compared numerically, strings lexically, and other values for equality only.
+func compareJSONValues(left interface{}, operator string, right interface{}) bool {
+	if leftNumber, ok := jsonNumberValue(left); ok {
+		if rightNumber, ok := jsonNumberValue(right); ok {
+			return compareOrdered(leftNumber, operator, rightNumber)
+		}
+	}
+	if leftString, ok := left.(string); ok {
+		if rightString, ok := right.(string); ok {
+			return compareOrdered(leftString, operator, rightString)
+		}
+	}
+
+	switch operator {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	default:
+		return false
+	}
+}
+
+func compareOrdered[T float64 | string](left T, operator string, right T) bool {
+	switch operator {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	default:
+		return false
+	}
+}
+
+// jsonNumberValue returns the numeric value of a decoded JSON number.
+func jsonNumberValue(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	case float64:
+		return typed, true
+	case int64:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	default:
+		return 0, false
+	}
+}
+
+// jsonPathParser parses JSONPath expressions.
+type jsonPathParser struct {
+	expr string
+	pos  int
+}
+
+func (p *jsonPathParser) errorf(format string, args ...interface{}) error {
+	return CroissantError{
+		Message: fmt.Sprintf("invalid JSONPath at offset %d: %s", p.pos, fmt.Sprintf(format, args...)),
+		Value:   p.expr,
+	}
+}
+
+// parse parses a whole expression. The root "$" (or "@" in filters) is optional.
+func (p *jsonPathParser) parse() ([]jsonPathSegment, error) {
+	if p.expr == "" {
+		return nil, p.errorf("empty expression")
+	}
+
+	var segments []jsonPathSegment
+	switch {
+	case p.expr[0] == '$' || p.expr[0] == '@':
+		p.pos++
+	case isJSONPathNameByte(p.expr[0]):
+		// A bare name such as "annotations.id" is relative to the root.
+		segments = append(segments, jsonPathSegment{selector: nameSelector{names: []string{p.readName()}}})
+	}
+
+	for p.pos < len(p.expr) {
+		segment, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+
+	return segments, nil
+}
+
+func (p *jsonPathParser) parseSegment() (jsonPathSegment, error) {
+	switch {
+	case strings.HasPrefix(p.expr[p.pos:], ".."):
+		p.pos += 2
+		if p.pos < len(p.expr) && p.expr[p.pos] == '[' {
+			selector, err := p.parseBracket()
+			return jsonPathSegment{recursive: true, selector: selector}, err
+		}
+		selector, err := p.parseDotSelector()
+		return jsonPathSegment{recursive: true, selector: selector}, err
+	case p.expr[p.pos] == '.':
+		p.pos++
+		selector, err := p.parseDotSelector()
+		return jsonPathSegment{selector: selector}, err
+	case p.expr[p.pos] == '[':
+		selector, err := p.parseBracket()
+		return jsonPathSegment{selector: selector}, err
+	default:
+		return jsonPathSegment{}, p.errorf("unexpected character %q", p.expr[p.pos])
+	}
+}
+
+// parseDotSelector parses the name or wildcard following a dot.
+func (p *jsonPathParser) parseDotSelector() (jsonPathSelector, error) {
+	if p.pos < len(p.expr) && p.expr[p.pos] == '*' {
+		p.pos++
+		return wildcardSelector{}, nil
+	}
+	name := p.readName()
+	if name == "" {
+		return nil, p.errorf("expected a member name")
+	}
+
+	return nameSelector{names: []string{name}}, nil
+}
+
+// readName reads an unquoted member name.
+func (p *jsonPathParser) readName() string {
+	start := p.pos
+	for p.pos < len(p.expr) && isJSONPathNameByte(p.expr[p.pos]) {
+		p.pos++
+	}
+
+	return p.expr[start:p.pos]
+}
+
+func isJSONPathNameByte(c byte) bool {
+	return c != '.' && c != '[' && c != ']' && c != ' ' && c != '$' && c != '@' &&
+		c != '=' && c != '!' && c != '<' && c != '>' && c != '&' && c != '|' && c != ')' && c != '(' &&
+		c != '\'' && c != '"' && c != ',' && c != '*'
+}
+
+// parseBracket parses a bracketed selector: wildcard, filter, slice or union.
+func (p *jsonPathParser) parseBracket() (jsonPathSelector, error) {
+	end, err := p.findClosingBracket()
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimSpace(p.expr[p.pos+1 : end])
+	p.pos = end + 1
+
+	switch {
+	case content == "*":
+		return wildcardSelector{}, nil
+	case strings.HasPrefix(content, "?"):
+		return parseJSONPathFilter(strings.TrimSpace(content[1:]), p.expr)
+	case strings.ContainsRune(content, ':') && content[0] != '\'' && content[0] != '"':
+		return parseSliceSelector(content, p.expr)
+	}
+
+	var names []string
+	var indices []int
+	for _, part := range splitOutsideQuotes(content, ',') {
+		part = strings.TrimSpace(part)
+		if name, ok := unquoteJSONPathString(part); ok {
+			names = append(names, name)
+			continue
+		}
+		index, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, CroissantError{Message: "invalid JSONPath bracket selector", Value: p.expr}
+		}
+		indices = append(indices, index)
+	}
+	switch {
+	case len(names) > 0 && len(indices) > 0:
+		return nil, CroissantError{Message: "JSONPath unions cannot mix names and indices", Value: p.expr}
+	case len(names) > 0:
+		return nameSelector{names: names}, nil
+	case len(indices) > 0:
+		return indexSelector{indices: indices}, nil
+	default:
+		return nil, CroissantError{Message: "empty JSONPath bracket selector", Value: p.expr}
+	}
+}
+
+// findClosingBracket returns the offset of the bracket closing the one at the current position.
+func (p *jsonPathParser) findClosingBracket() (int, error) {
+	depth := 0
+	var quote byte
+	for i := p.pos; i < len(p.expr); i++ {
+		c := p.expr[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, p.errorf("unterminated bracket")
+}
+
+// parseSliceSelector parses "start:end:step".
+func parseSliceSelector(content string, expr string) (jsonPathSelector, error) {
+	parts := strings.Split(content, ":")
+	if len(parts) > 3 {
+		return nil, CroissantError{Message: "invalid JSONPath slice", Value: expr}
+	}
+
+	selector := sliceSelector{step: 1}
+	bounds := []**int{&selector.start, &selector.end}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, CroissantError{Message: "invalid JSONPath slice", Value: expr}
+		}
+		if i < 2 {
+			*bounds[i] = &value
+		} else {
+			selector.step = value
+		}
+	}
+	if selector.step == 0 {
+		return nil, CroissantError{Message: "JSONPath slice step cannot be zero", Value: expr}
+	}
+
+	return selector, nil
+}
+
+// parseJSONPathFilter parses the "(...)" expression of a filter selector.
+func parseJSONPathFilter(content string, expr string) (jsonPathSelector, error) {
+	if !strings.HasPrefix(content, "(") || !strings.HasSuffix(content, ")") {
+		return nil, CroissantError{Message: "JSONPath filters must have the form ?(...)", Value: expr}
+	}
+	content = content[1 : len(content)-1]
+
+	var filter jsonPathFilter
+	for _, disjunct := range splitOutsideQuotes(content, '|') {
+		disjunct = strings.TrimSpace(disjunct)
+		if disjunct == "" {
+			continue // the second half of "||"
+		}
+		var conjuncts []jsonPathComparison
+		for _, conjunct := range splitOutsideQuotes(disjunct, '&') {
+			conjunct = strings.TrimSpace(conjunct)
+			if conjunct == "" {
+				continue // the second half of "&&"
+			}
+			comparison, err := parseJSONPathComparison(conjunct, expr)
+			if err != nil {
+				return nil, err
+			}
+			conjuncts = append(conjuncts, comparison)
+		}
+		filter.disjuncts = append(filter.disjuncts, conjuncts)
+	}
+	if len(filter.disjuncts) == 0 {
+		return nil, CroissantError{Message: "empty JSONPath filter", Value: expr}
+	}
+
+	return filterSelector{filter: filter}, nil
+}
+
+// parseJSONPathComparison parses "@.path", or "@.path <operator> <literal>".
+func parseJSONPathComparison(content string, expr string) (jsonPathComparison, error) {
+	if !strings.HasPrefix(content, "@") {
+		return jsonPathComparison{}, CroissantError{Message: "JSONPath filters must start with @", Value: expr}
+	}
+
+	operatorAt, operator := -1, ""
+	var quote byte
+	for i := 0; i < len(content) && operatorAt < 0; i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.ContainsRune("=!<>", rune(c)):
+			operatorAt, operator = i, string(c)
+			if i+1 < len(content) && content[i+1] == '=' {
+				operator += "="
+			}
+		}
+	}
+
+	var comparison jsonPathComparison
+	pathExpr := content
+	if operatorAt >= 0 {
+		if operator == "=" || operator == "!" {
+			return jsonPathComparison{}, CroissantError{Message: "invalid JSONPath filter operator", Value: expr}
+		}
+		pathExpr = strings.TrimSpace(content[:operatorAt])
+		literal, err := parseJSONPathLiteral(strings.TrimSpace(content[operatorAt+len(operator):]))
+		if err != nil {
+			return jsonPathComparison{}, CroissantError{Message: "invalid JSONPath filter literal", Value: expr}
+		}
+		comparison.operator = operator
+		comparison.literal = literal
+	}
+
+	path, err := compileJSONPath(pathExpr)
+	if err != nil {
+		return jsonPathComparison{}, err
+	}
+	comparison.path = path
+
+	return comparison, nil
+}
+
+// parseJSONPathLiteral parses a quoted string, number, boolean or null.
+func parseJSONPathLiteral(literal string) (interface{}, error) {
+	if str, ok := unquoteJSONPathString(literal); ok {
+		return str, nil
+	}
+	switch literal {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	return strconv.ParseFloat(literal, 64)
+}
+
+// unquoteJSONPathString returns the content of a single- or double-quoted string.
+func unquoteJSONPathString(str string) (string, bool) {
+	if len(str) < 2 || (str[0] != '\'' && str[0] != '"') || str[len(str)-1] != str[0] {
+		return "", false
+	}
+
+	var unquoted strings.Builder
+	for i := 1; i < len(str)-1; i++ {
+		if str[i] == '\\' && i+1 < len(str)-1 {
+			i++
+		}
+		unquoted.WriteByte(str[i])
+	}
+
+	return unquoted.String(), true
+}
+
+// splitOutsideQuotes splits a string on a separator that is not inside quotes.
+func splitOutsideQuotes(str string, separator byte) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == separator:
+			parts = append(parts, str[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, str[start:])
+}
@@ -0,0 +1,161 @@
+// File: pkg/croissant/jsonpath_test.go
+package croissant
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONPathEvaluate(t *testing.T) {
+	document, err := decodeJSON(strings.NewReader(`{
+		"info": {"version": "1.0"},
+		"annotations": [
+			{"id": 1, "label": "cat", "score": 0.9, "tags": ["a", "b"]},
+			{"id": 2, "label": "dog", "score": 0.4},
+			{"id": 3, "label": "cat", "score": 0.2}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		expr     string
+		want     []string
+		definite bool
+	}{
+		{"$.info.version", []string{"1.0"}, true},
+		{"info.version", []string{"1.0"}, true},
+		{"$['info']['version']", []string{"1.0"}, true},
+		{"$.annotations[0].label", []string{"cat"}, true},
+		{"$.annotations[-1].id", []string{"3"}, true},
+		{"$.annotations[*].id", []string{"1", "2", "3"}, false},
+		{"$.annotations[0,2].id", []string{"1", "3"}, false},
+		{"$.annotations[1:].id", []string{"2", "3"}, false},
+		{"$.annotations[::-1].id", []string{"3", "2", "1"}, false},
+		{"$..version", []string{"1.0"}, false},
+		{"$.annotations[?(@.label == 'cat')].id", []string{"1", "3"}, false},
+		{"$.annotations[?(@.label == 'cat' && @.score > 0.5)].id", []string{"1"}, false},
+		{"$.annotations[?(@.score < 0.3 || @.label == \"dog\")].id", []string{"2", "3"}, false},
+		{"$.annotations[?(@.tags)].id", []string{"1"}, false},
+		{"$.missing", nil, true},
+	}
+
+	for _, c := range cases {
+		path, err := compileJSONPath(c.expr)
+		if err != nil {
+			t.Fatalf("compileJSONPath(%q): %v", c.expr, err)
+		}
+		if path.Definite() != c.definite {
+			t.Errorf("%q: Definite() = %v, want %v", c.expr, path.Definite(), c.definite)
+		}
+		var got []string
+		for _, match := range path.Evaluate(document) {
+			got = append(got, stringifyValue(match))
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%q = %v, want %v", c.expr, got, c.want)
+		}
+	}
+}
+
+func TestCompileJSONPathErrors(t *testing.T) {
+	for _, expr := range []string{"", "$.a[", "$.a[1:2:0]", "$.a[?(@.b = 1)]", "$.a['b',0]"} {
+		if _, err := compileJSONPath(expr); err == nil {
+			t.Errorf("compileJSONPath(%q) should fail", expr)
+		}
+	}
+}
+
+// jsonTestMetadata returns metadata with one record set extracted from a JSON-based distribution.
+func jsonTestMetadata(contentURL string, encodingFormat string, fields ...Field) Metadata {
+	return Metadata{
+		Type: "sc:Dataset",
+		Name: "annotations",
+		Distributions: []Distribution{
+			{ID: "annotations-file", Type: "cr:FileObject", Name: "annotations-file", ContentURL: contentURL, EncodingFormat: encodingFormat},
+		},
+		RecordSets: []RecordSet{
+			{ID: "annotations", Type: "cr:RecordSet", Name: "annotations", Fields: fields},
+		},
+	}
+}
+
+func jsonTestField(name string, jsonPath string, dataType string, repeated bool) Field {
+	return Field{
+		ID:       "annotations/" + name,
+		Type:     "cr:Field",
+		Name:     name,
+		DataType: NewSingleDataType(dataType),
+		Repeated: repeated,
+		Source: FieldSource{
+			FileObject: FileObject{ID: "annotations-file"},
+			Extract:    Extract{JSONPath: jsonPath},
+		},
+	}
+}
+
+func TestRecordsJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "annotations.json", `{
+		"info": {"version": "2"},
+		"annotations": [
+			{"id": 1, "bbox": [1.5, 2, 3, 4]},
+			{"id": 2, "bbox": [5, 6, 7, 8]}
+		]
+	}`)
+
+	metadata := jsonTestMetadata("annotations.json", "application/json",
+		jsonTestField("id", "$.annotations[*].id", VT_scInt, false),
+		jsonTestField("bbox", "$.annotations[*].bbox", VT_scFloat, true),
+		jsonTestField("version", "$.info.version", VT_scText, false),
+	)
+
+	var records []Record
+	for record, err := range RecordsWithOptions(context.Background(), metadata, "annotations", RecordOptions{BaseDir: dir}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	want := []Record{
+		{"annotations/id": int64(1), "annotations/bbox": []interface{}{1.5, 2.0, 3.0, 4.0}, "annotations/version": "2"},
+		{"annotations/id": int64(2), "annotations/bbox": []interface{}{5.0, 6.0, 7.0, 8.0}, "annotations/version": "2"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
+
+func TestRecordsJSONLines(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "annotations.jsonl", `{"id": 1, "labels": [{"name": "cat"}, {"name": "dog"}], "meta": {"ok": true}}
+
+{"id": 2, "labels": [], "meta": {"ok": false}}
+`)
+
+	metadata := jsonTestMetadata("annotations.jsonl", "application/jsonl",
+		jsonTestField("id", "$.id", VT_scInt, false),
+		jsonTestField("labels", "$.labels[*].name", VT_scText, true),
+		jsonTestField("ok", "$.meta.ok", VT_scBool, false),
+	)
+
+	var records []Record
+	for record, err := range RecordsWithOptions(context.Background(), metadata, "annotations", RecordOptions{BaseDir: dir}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	want := []Record{
+		{"annotations/id": int64(1), "annotations/labels": []interface{}{"cat", "dog"}, "annotations/ok": true},
+		{"annotations/id": int64(2), "annotations/labels": []interface{}{}, "annotations/ok": false},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
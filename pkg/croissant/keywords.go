@@ -0,0 +1,182 @@
+// keywords.go
+// Models dataset keywords, as free text or as terms defined in a vocabulary.
+package croissant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefinedTermType is the type of a keyword defined in a vocabulary.
+const DefinedTermType = "sc:DefinedTerm"
+
+// DefinedTerm represents a schema.org DefinedTerm, a word or phrase defined in a vocabulary.
+type DefinedTerm struct {
+	ID string `json:"@id,omitempty"`
+	// Type of the term, DefinedTermType.
+	Type        string `json:"@type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Code of the term in its vocabulary, e.g. "Q11660" for artificial intelligence in Wikidata.
+	TermCode string `json:"termCode,omitempty"`
+	// Url of the vocabulary, i.e. the DefinedTermSet, that defines the term.
+	InDefinedTermSet string `json:"inDefinedTermSet,omitempty"`
+	// Url of the term's webpage.
+	URL string `json:"url,omitempty"`
+	// Properties the struct does not model, e.g. "sameAs", by name.
+	// They are kept when decoding and emitted when encoding.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for DefinedTerm, keeping unmodelled properties in Extra.
+func (d *DefinedTerm) UnmarshalJSON(data []byte) error {
+	type plainDefinedTerm DefinedTerm
+	var plain plainDefinedTerm
+	extra, err := unmarshalWithExtra(data, &plain)
+	if err != nil {
+		return err
+	}
+	*d = DefinedTerm(plain)
+	d.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for DefinedTerm, including the properties in Extra.
+func (d DefinedTerm) MarshalJSON() ([]byte, error) {
+	type plainDefinedTerm DefinedTerm
+	return marshalWithExtra(plainDefinedTerm(d), d.Extra)
+}
+
+// Keyword represents a keyword of a dataset, either as free text or as a DefinedTerm.
+type Keyword struct {
+	// Free text of the keyword. Unused if DefinedTerm is set.
+	Text string
+	// Term with a formal definition, or nil for free text keywords.
+	DefinedTerm *DefinedTerm
+}
+
+// NewDefinedTermKeyword creates a keyword for the term with the given name and code,
+// defined in the vocabulary at termSetURL.
+func NewDefinedTermKeyword(name string, termCode string, termSetURL string) Keyword {
+	return Keyword{DefinedTerm: &DefinedTerm{
+		Type:             DefinedTermType,
+		Name:             name,
+		TermCode:         termCode,
+		InDefinedTermSet: termSetURL,
+	}}
+}
+
+// String returns the text of the keyword: its free text, or the name of its DefinedTerm,
+// or the term code if the term has no name.
+func (k Keyword) String() string {
+	if k.DefinedTerm == nil {
+		return k.Text
+	}
+	if k.DefinedTerm.Name != "" {
+		return k.DefinedTerm.Name
+	}
+
+	return k.DefinedTerm.TermCode
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Keyword.
+//
+// Accepts:
+//   - "keywords": "..."
+//   - "keywords": { "@type": "sc:DefinedTerm", "name": "...", "termCode": "...", "inDefinedTermSet": "..." }
+func (k *Keyword) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*k = Keyword{Text: text}
+
+		return nil
+	}
+
+	var term DefinedTerm
+	if err := json.Unmarshal(data, &term); err == nil {
+		*k = Keyword{DefinedTerm: &term}
+
+		return nil
+	}
+
+	return CroissantError{
+		Message: "Keyword: cannot unmarshal",
+		Value:   string(data),
+	}
+}
+
+// MarshalJSON implements custom JSON marshaling for Keyword.
+func (k Keyword) MarshalJSON() ([]byte, error) {
+	if k.DefinedTerm != nil {
+		return json.Marshal(k.DefinedTerm)
+	}
+
+	return json.Marshal(k.Text)
+}
+
+// Keywords represents the keywords of a dataset.
+type Keywords []Keyword
+
+// NewKeywords creates free text keywords.
+func NewKeywords(texts ...string) Keywords {
+	keywords := make(Keywords, 0, len(texts))
+	for _, text := range texts {
+		keywords = append(keywords, Keyword{Text: text})
+	}
+
+	return keywords
+}
+
+// Strings returns the text of each keyword, e.g. for search and indexing.
+func (k Keywords) Strings() []string {
+	texts := make([]string, 0, len(k))
+	for _, keyword := range k {
+		texts = append(texts, keyword.String())
+	}
+
+	return texts
+}
+
+// Contains returns true if a keyword has the given text, ignoring case, or if a
+// DefinedTerm keyword has the given name or term code.
+func (k Keywords) Contains(text string) bool {
+	for _, keyword := range k {
+		if strings.EqualFold(keyword.String(), text) {
+			return true
+		}
+		if keyword.DefinedTerm != nil && (strings.EqualFold(keyword.DefinedTerm.Name, text) || keyword.DefinedTerm.TermCode == text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateKeywords validates the keywords of a dataset.
+func validateKeywords(node *MetadataNode, keywords Keywords, issues *Issues) {
+	for i, keyword := range keywords {
+		term := keyword.DefinedTerm
+		if term == nil {
+			if strings.TrimSpace(keyword.Text) == "" {
+				issues.AddWarning(fmt.Sprintf("Keyword #%d is empty.", i+1), node)
+			}
+			continue
+		}
+
+		label := fmt.Sprintf("Keyword #%d", i+1)
+		if keyword.String() != "" {
+			label = fmt.Sprintf("Keyword \"%s\"", keyword.String())
+		}
+		if term.Type != "" && schemaOrgTypeName(term.Type) != "DefinedTerm" {
+			issues.AddError(fmt.Sprintf("%s should have an attribute \"@type\": \"%s\". Got %s instead.", label, DefinedTermType, term.Type), node)
+		}
+		if term.Name == "" && term.TermCode == "" {
+			issues.AddError(fmt.Sprintf("%s must have a \"name\" or a \"termCode\".", label), node)
+		}
+		if term.InDefinedTermSet != "" && !isValidAbsoluteURL(term.InDefinedTermSet) {
+			issues.AddWarning(fmt.Sprintf("%s has an \"inDefinedTermSet\" that is not a URL: \"%s\".", label, term.InDefinedTermSet), node)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// File: pkg/croissant/keywords_test.go
+package croissant
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseKeywords(t *testing.T) {
+	document := `{
+		"@context": {"@vocab": "https://schema.org/", "sc": "https://schema.org/"},
+		"@type": "sc:Dataset",
+		"name": "cities",
+		"keywords": [
+			"geography",
+			{
+				"@type": "DefinedTerm",
+				"name": "artificial intelligence",
+				"termCode": "Q11660",
+				"inDefinedTermSet": "https://www.wikidata.org/"
+			}
+		]
+	}`
+	metadata, err := NewJSONLDProcessor().ParseCroissantMetadata([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Keywords{
+		{Text: "geography"},
+		NewDefinedTermKeyword("artificial intelligence", "Q11660", "https://www.wikidata.org/"),
+	}
+	if !reflect.DeepEqual(metadata.Keywords, want) {
+		t.Errorf("Keywords = %+v, want %+v", metadata.Keywords, want)
+	}
+	if got := metadata.Keywords.Strings(); !reflect.DeepEqual(got, []string{"geography", "artificial intelligence"}) {
+		t.Errorf("Strings() = %v", got)
+	}
+	if !metadata.Keywords.Contains("Geography") || !metadata.Keywords.Contains("Q11660") || metadata.Keywords.Contains("history") {
+		t.Errorf("Contains() does not match the keywords %v", metadata.Keywords.Strings())
+	}
+
+	encoded, err := json.Marshal(metadata.Keywords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEncoded := `["geography",{"@type":"sc:DefinedTerm","name":"artificial intelligence","termCode":"Q11660","inDefinedTermSet":"https://www.wikidata.org/"}]`
+	if string(encoded) != wantEncoded {
+		t.Errorf("encoded keywords = %s, want %s", encoded, wantEncoded)
+	}
+
+	single, err := NewJSONLDProcessor().ParseCroissantMetadata([]byte(strings.Replace(document, `[
+			"geography",`, `[`, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(single.Keywords) != 1 || single.Keywords[0].DefinedTerm == nil {
+		t.Errorf("Keywords = %+v, want a single DefinedTerm", single.Keywords)
+	}
+}
+
+func TestValidateKeywords(t *testing.T) {
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt))
+	metadata.Keywords = Keywords{
+		{Text: "cities"},
+		{DefinedTerm: &DefinedTerm{Type: "sc:Thing", Name: "towns"}},
+		{DefinedTerm: &DefinedTerm{Type: DefinedTermType, InDefinedTermSet: "wikidata"}},
+	}
+
+	report := ValidateMetadata(metadata).Report()
+	for _, want := range []string{
+		`Keyword "towns" should have an attribute "@type": "sc:DefinedTerm". Got sc:Thing instead.`,
+		`Keyword #3 must have a "name" or a "termCode".`,
+		`Keyword #3 has an "inDefinedTermSet" that is not a URL: "wikidata".`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "cities") {
+		t.Errorf("free text keyword should not be reported:\n%s", report)
+	}
+}
@@ -15,6 +15,8 @@ type MetadataNode struct {
 	Distributions []*DistributionNode `json:"distribution"`
 	RecordSets    []*RecordSetNode    `json:"recordSet"`
 	Issues        *Issues             `json:"-"` // Not serialized to JSON
+	// Metadata the node was converted from, used to resolve files.
+	metadata Metadata
 }
 
 // NewMetadataNode creates a new MetadataNode.
@@ -74,6 +76,7 @@ func FromMetadata(metadata Metadata) *MetadataNode {
 		DatePublished: metadata.DatePublished,
 		Version:       metadata.Version,
 		Issues:        NewIssues(),
+		metadata:      metadata,
 	}
 
 	// Convert distributions
@@ -88,6 +91,10 @@ func FromMetadata(metadata Metadata) *MetadataNode {
 			ContentURL:     dist.ContentURL,
 			EncodingFormat: dist.EncodingFormat,
 			SHA256:         dist.SHA256,
+			MD5:            dist.MD5,
+			ContainedIn:    dist.ContainedIn,
+			Includes:       dist.Includes,
+			Excludes:       dist.Excludes,
 		}
 		distNode.SetParent(node)
 		node.Distributions = append(node.Distributions, distNode)
@@ -154,9 +161,10 @@ func convertFieldToNode(field Field, parent Node) *FieldNode {
 			Transform: field.Source.Transform,
 			Format:    field.Source.Format,
 		},
-		Repeated:   field.Repeated,
-		Examples:   field.Examples,
-		References: field.References,
+		Repeated:    field.Repeated,
+		Examples:    field.Examples,
+		ParentField: field.ParentField,
+		References:  field.References,
 	}
 	fieldNode.SetParent(parent)
 
@@ -172,12 +180,15 @@ func convertFieldToNode(field Field, parent Node) *FieldNode {
 // DistributionNode represents a file distribution.
 type DistributionNode struct {
 	BaseNode
-	Type           string `json:"@type"`
-	ContentSize    string `json:"contentSize,omitempty"`
-	ContentURL     string `json:"contentUrl,omitempty"`
-	EncodingFormat string `json:"encodingFormat,omitempty"`
-	SHA256         string `json:"sha256,omitempty"`
-	MD5            string `json:"md5,omitempty"`
+	Type           string         `json:"@type"`
+	ContentSize    string         `json:"contentSize,omitempty"`
+	ContentURL     string         `json:"contentUrl,omitempty"`
+	EncodingFormat string         `json:"encodingFormat,omitempty"`
+	SHA256         string         `json:"sha256,omitempty"`
+	MD5            string         `json:"md5,omitempty"`
+	ContainedIn    *FileObjectRef `json:"containedIn,omitempty"`
+	Includes       string         `json:"includes,omitempty"`
+	Excludes       string         `json:"excludes,omitempty"`
 }
 
 // Validate validates the distribution node.
@@ -192,10 +203,13 @@ func (d *DistributionNode) Validate(issues *Issues) {
 		issues.AddError(fmt.Sprintf("\"%s\" should have an attribute \"@type\": \"http://mlcommons.org/croissant/FileObject\" or \"@type\": \"http://mlcommons.org/croissant/FileSet\". Got %s instead.", d.Name, d.Type), d)
 	}
 
-	// Validate content URL
-	if d.ContentURL == "" {
+	// Validate content URL; FileSets locate their files through containedIn and includes
+	if d.ContentURL == "" && d.needsContentURL() {
 		issues.AddError("Property \"https://schema.org/contentUrl\" is mandatory, but does not exist.", d)
 	}
+	if d.Type == "cr:FileSet" && d.Includes == "" {
+		issues.AddError("Property \"http://mlcommons.org/croissant/includes\" is mandatory for FileSets, but does not exist.", d)
+	}
 
 	// Validate encoding format
 	if d.EncodingFormat == "" {
@@ -203,6 +217,16 @@ func (d *DistributionNode) Validate(issues *Issues) {
 	}
 }
 
+// needsContentURL returns true if the distribution locates its files through its content URL.
+// FileSets with a containedIn or includes locate them within their container or the base directory.
+func (d *DistributionNode) needsContentURL() bool {
+	if d.Type != "cr:FileSet" {
+		return true
+	}
+
+	return (d.ContainedIn == nil || d.ContainedIn.ID == "") && d.Includes == ""
+}
+
 // RecordSetNode represents a record set.
 type RecordSetNode struct {
 	BaseNode
@@ -303,6 +327,24 @@ func (r *RecordSetNode) validateSplit(issues *Issues) {
 	}
 }
 
+// keyFieldNode returns the field a key ID refers to. Key IDs may be field IDs or names,
+// or IDs relative to the record set (e.g. "id" for the field "captions/id").
+// Returns nil if the record set has no such field.
+func keyFieldNode(rs *RecordSetNode, keyID string) *FieldNode {
+	for _, field := range rs.Fields {
+		if field != nil && (field.ID == keyID || field.Name == keyID) {
+			return field
+		}
+	}
+	for _, field := range rs.Fields {
+		if field != nil && field.ID == recordSetNodeKey(rs)+"/"+keyID {
+			return field
+		}
+	}
+
+	return nil
+}
+
 // validateKey validates that key references point to existing fields.
 func (r *RecordSetNode) validateKey(issues *Issues) {
 	if r.Key == nil {
@@ -315,20 +357,9 @@ func (r *RecordSetNode) validateKey(issues *Issues) {
 		return
 	}
 
-	// Build a map of available field IDs for this record set
-	fieldIDs := make(map[string]bool)
-	for _, field := range r.Fields {
-		if field.ID != "" {
-			fieldIDs[field.ID] = true
-		}
-		if field.Name != "" {
-			fieldIDs[field.Name] = true
-		}
-	}
-
 	// Check that all key IDs reference existing fields
 	for _, keyID := range keyIDs {
-		if !fieldIDs[keyID] {
+		if keyFieldNode(r, keyID) == nil {
 			if r.Key.IsComposite() {
 				issues.AddError(fmt.Sprintf("Composite key references non-existent field \"%s\"", keyID), r)
 			} else {
@@ -348,6 +379,7 @@ type FieldNode struct {
 	Repeated    bool          `json:"repeated,omitempty"`
 	Examples    interface{}   `json:"examples,omitempty"`
 	SubField    []*FieldNode  `json:"subField,omitempty"`
+	ParentField FieldRefSlice `json:"parentField,omitempty"`
 	References  FieldRefSlice `json:"references,omitempty"`
 }
 
@@ -375,39 +407,25 @@ func (f *FieldNode) Validate(issues *Issues) {
 		}
 	}
 
-	// Validate source - but skip validation for fields in enumeration RecordSets with inline data
-	if !f.Source.ValidateSource() {
-		// Check if this field belongs to an enumeration RecordSet with inline data
-		if parent := f.GetParent(); parent != nil {
-			if recordSet, ok := parent.(*RecordSetNode); ok {
-				// If it's an enumeration with inline data, skip source validation
-				if recordSet.DataType.GetFirstType() == VT_scEnum && len(recordSet.Data) > 0 {
-					// Skip validation for enumeration fields with inline data
-				} else {
-					issues.AddError(fmt.Sprintf("Node \"%s\" is a field and has no source. Please, use http://mlcommons.org/croissant/source to specify the source.", f.ID), f)
-				}
-			} else {
-				issues.AddError(fmt.Sprintf("Node \"%s\" is a field and has no source. Please, use http://mlcommons.org/croissant/source to specify the source.", f.ID), f)
-			}
-		} else {
-			issues.AddError(fmt.Sprintf("Node \"%s\" is a field and has no source. Please, use http://mlcommons.org/croissant/source to specify the source.", f.ID), f)
-		}
+	// Validate source - but skip validation for fields of RecordSets with inline data
+	if !f.Source.ValidateSource() && !hasInlineData(f) {
+		issues.AddError(fmt.Sprintf("Node \"%s\" is a field and has no source. Please, use http://mlcommons.org/croissant/source to specify the source.", f.ID), f)
 	}
 }
 
 // SourceNode represents a source.
 type SourceNode struct {
-	Extract    ExtractNode   `json:"extract,omitempty"`
-	FileObject FileObjectRef `json:"fileObject,omitempty"`
-	FileSet    FileObjectRef `json:"fileSet,omitempty"`
-	Transform  Transform     `json:"transform,omitempty"`
-	Format     string        `json:"format,omitempty"`
+	Extract    ExtractNode    `json:"extract,omitempty"`
+	FileObject FileObjectRef  `json:"fileObject,omitempty"`
+	FileSet    FileObjectRef  `json:"fileSet,omitempty"`
+	Transform  TransformSlice `json:"transform,omitempty"`
+	Format     string         `json:"format,omitempty"`
 }
 
 // ValidateSource validates the source node.
 func (s *SourceNode) ValidateSource() bool {
 	// Check if either extract has content or file object/set references are valid
-	hasExtract := s.Extract.Column != "" || s.Extract.JSONPath != "" || s.Extract.FileProperty != ""
+	hasExtract := s.Extract.Column != "" || s.Extract.JSONPath != "" || IsValidFileProperty(s.Extract.FileProperty)
 	hasFileRef := s.FileObject.ID != "" || s.FileSet.ID != ""
 
 	return hasExtract && hasFileRef
@@ -0,0 +1,115 @@
+// nested.go
+// Assembles nested values for fields with subfields, and slices for repeated fields.
+package croissant
+
+import (
+	"fmt"
+)
+
+// leafFields returns the fields whose values are read from a source: the fields
+// without subfields, and the innermost subfields of the others, in declaration order.
+// Subfields without a source distribution inherit the distribution of their parent.
+func leafFields(fields []Field) []Field {
+	var leaves []Field
+	for _, field := range fields {
+		if len(field.SubField) == 0 {
+			leaves = append(leaves, field)
+			continue
+		}
+
+		subFields := make([]Field, len(field.SubField))
+		for i, subField := range field.SubField {
+			if fieldSourceID(subField) == "" {
+				subField.Source.FileObject = field.Source.FileObject
+				subField.Source.FileSet = field.Source.FileSet
+			}
+			subFields[i] = subField
+		}
+		leaves = append(leaves, leafFields(subFields)...)
+	}
+
+	return leaves
+}
+
+// nestValue builds the value of a field from the converted values of the leaf fields.
+//
+// Fields with subfields become a Record of their subfield values keyed by subfield
+// key. Repeated fields become a slice: the values of a repeated field with subfields
+// are zipped element by element into a slice of Records, and single values of a
+// repeated field without subfields are wrapped in a slice. Null values stay nil.
+// Returns false if the field has no value, because every leaf failed to convert.
+func nestValue(field Field, values map[string]interface{}) (interface{}, bool, error) {
+	if len(field.SubField) == 0 {
+		value, exists := values[FieldKey(field)]
+		if !exists {
+			return nil, false, nil
+		}
+		if field.Repeated && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				value = []interface{}{value}
+			}
+		}
+		return value, true, nil
+	}
+
+	nested := make(Record, len(field.SubField))
+	for _, subField := range field.SubField {
+		value, exists, err := nestValue(subField, values)
+		if err != nil {
+			return nil, false, err
+		}
+		if exists {
+			nested[FieldKey(subField)] = value
+		}
+	}
+	if len(nested) == 0 {
+		return nil, false, nil
+	}
+	if !field.Repeated {
+		return nested, true, nil
+	}
+
+	return zipRecord(nested, field.SubField)
+}
+
+// zipRecord turns a Record whose values are slices into a slice of Records.
+// Values that are not slices are repeated in every Record.
+// Returns an error if the slices have different lengths, naming the first subfield,
+// in declaration order, whose length differs from the ones before it.
+func zipRecord(nested Record, subFields []Field) (interface{}, bool, error) {
+	length := -1
+	for _, subField := range subFields {
+		key := FieldKey(subField)
+		value := nested[key]
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		if length >= 0 && len(items) != length {
+			return nil, false, CroissantError{
+				Message: fmt.Sprintf("subfield %q has %d values, expected %d", key, len(items), length),
+				Value:   value,
+			}
+		}
+		length = len(items)
+	}
+	if length < 0 {
+		// No subfield is repeated: a single nested value.
+		return []interface{}{nested}, true, nil
+	}
+
+	records := make([]interface{}, length)
+	for i := range records {
+		record := make(Record, len(nested))
+		for key, value := range nested {
+			if items, ok := value.([]interface{}); ok {
+				record[key] = items[i]
+			} else {
+				record[key] = value
+			}
+		}
+		records[i] = record
+	}
+
+	return records, true, nil
+}
@@ -0,0 +1,126 @@
+// File: pkg/croissant/nested_test.go
+package croissant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// subField returns a subfield of a field extracting a value through a jsonPath.
+func subField(parent string, name string, dataType string, path string) Field {
+	return Field{
+		ID:       parent + "/" + name,
+		Type:     "cr:Field",
+		Name:     name,
+		DataType: NewSingleDataType(dataType),
+		Source:   FieldSource{Extract: Extract{JSONPath: path}},
+	}
+}
+
+func TestRecordsSubFields(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id,x,y,w,h\n1,10,20,30,40\n")
+
+	bbox := Field{
+		ID:       "main/bbox",
+		Type:     "cr:Field",
+		Name:     "bbox",
+		DataType: NewSingleDataType(VT_crBBox),
+		SubField: []Field{
+			csvTestField("x", VT_scFloat),
+			csvTestField("y", VT_scFloat),
+			csvTestField("w", VT_scFloat),
+			csvTestField("h", VT_scFloat),
+		},
+	}
+	for i := range bbox.SubField {
+		bbox.SubField[i].ID = "main/bbox/" + bbox.SubField[i].Name
+	}
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt), bbox)
+
+	records := readTestRecords(t, metadata, "main", dir)
+	want := []Record{
+		{
+			"main/id": int64(1),
+			"main/bbox": Record{
+				"main/bbox/x": 10.0, "main/bbox/y": 20.0, "main/bbox/w": 30.0, "main/bbox/h": 40.0,
+			},
+		},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
+
+func TestRecordsRepeatedFields(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "images.jsonl",
+		`{"id": 1, "tags": "cat", "boxes": [{"label": "a", "area": 2}, {"label": "b", "area": 3}]}`+"\n"+
+			`{"id": 2, "tags": ["dog", "pet"], "boxes": []}`+"\n")
+
+	distribution := FileObject{ID: "images.jsonl"}
+	boxes := Field{
+		ID:       "images/boxes",
+		Type:     "cr:Field",
+		Name:     "boxes",
+		Repeated: true,
+		Source:   FieldSource{FileObject: distribution},
+		SubField: []Field{
+			subField("images/boxes", "label", VT_scText, "$.boxes[*].label"),
+			subField("images/boxes", "area", VT_scInt, "$.boxes[*].area"),
+		},
+	}
+	tags := subField("images", "tags", VT_scText, "$.tags")
+	tags.Repeated = true
+	tags.Source.FileObject = distribution
+	id := subField("images", "id", VT_scInt, "$.id")
+	id.Source.FileObject = distribution
+
+	metadata := Metadata{
+		Type: "sc:Dataset",
+		Name: "nested",
+		Distributions: []Distribution{
+			{ID: "images.jsonl", Type: "cr:FileObject", Name: "images.jsonl", ContentURL: "images.jsonl", EncodingFormat: "application/jsonl"},
+		},
+		RecordSets: []RecordSet{
+			{ID: "images", Type: "cr:RecordSet", Name: "images", Fields: []Field{id, tags, boxes}},
+		},
+	}
+
+	records := readTestRecords(t, metadata, "images", dir)
+	want := []Record{
+		{
+			"images/id":   int64(1),
+			"images/tags": []interface{}{"cat"},
+			"images/boxes": []interface{}{
+				Record{"images/boxes/label": "a", "images/boxes/area": int64(2)},
+				Record{"images/boxes/label": "b", "images/boxes/area": int64(3)},
+			},
+		},
+		{
+			"images/id":    int64(2),
+			"images/tags":  []interface{}{"dog", "pet"},
+			"images/boxes": []interface{}{},
+		},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
+
+func TestZipRecordLengthMismatch(t *testing.T) {
+	subFields := []Field{{ID: "boxes/label"}, {ID: "boxes/area"}, {ID: "boxes/score"}}
+	nested := Record{
+		"boxes/label": []interface{}{"a", "b"},
+		"boxes/area":  []interface{}{int64(2), int64(3), int64(4)},
+		"boxes/score": []interface{}{0.5},
+	}
+	want := `subfield "boxes/area" has 3 values, expected 2`
+	for range 20 {
+		_, _, err := zipRecord(nested, subFields)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("zipRecord error = %v, want %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+// parquet.go
+// Reads Parquet files for record sets and metadata generation.
+package croissant
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// EncodingFormat used for Parquet distributions.
+const parquetEncodingFormat = "application/x-parquet"
+
+// IsParquetFile checks if a file appears to be a Parquet file based on extension.
+func IsParquetFile(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".parquet"
+}
+
+// isParquetEncodingFormat returns true for the encoding formats used for Parquet files.
+func isParquetEncodingFormat(encodingFormat string) bool {
+	switch encodingFormat {
+	case parquetEncodingFormat, "application/parquet", "application/vnd.apache.parquet":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParquetColumn describes a column of a Parquet file.
+type ParquetColumn struct {
+	// Name of the column. Nested columns are joined with dots.
+	Name string
+	// Croissant data type inferred from the Parquet physical and logical types.
+	DataType string
+	// Whether the column holds a list of values.
+	Repeated bool
+}
+
+// GetParquetColumns reads the schema of a Parquet file and returns its columns.
+func GetParquetColumns(parquetPath string) ([]ParquetColumn, error) {
+	file, err := os.Open(filepath.Clean(parquetPath))
+	if err != nil {
+		return nil, CroissantError{Message: "failed to open Parquet file", Value: err}
+	}
+	defer file.Close()
+
+	parquetFile, err := openParquetFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := parquetFile.Schema()
+	columns := make([]ParquetColumn, 0, len(schema.Columns()))
+	for _, path := range schema.Columns() {
+		leaf, _ := schema.Lookup(path...)
+		columns = append(columns, ParquetColumn{
+			Name:     parquetColumnName(path),
+			DataType: parquetDataType(leaf.Node.Type()),
+			Repeated: leaf.MaxRepetitionLevel > 0,
+		})
+	}
+
+	return columns, nil
+}
+
+// parquetListSuffixes are the inner groups of the standard Parquet LIST encodings.
+var parquetListSuffixes = [][]string{{"list", "element"}, {"list", "item"}, {"array"}} //nolint:gochecknoglobals
+
+// parquetColumnName returns the name of a leaf column, without the inner groups of lists.
+func parquetColumnName(path []string) string {
+	for _, suffix := range parquetListSuffixes {
+		if len(path) > len(suffix) && slicesHaveSuffix(path, suffix) {
+			path = path[:len(path)-len(suffix)]
+			break
+		}
+	}
+
+	return strings.Join(path, ".")
+}
+
+func slicesHaveSuffix(path []string, suffix []string) bool {
+	offset := len(path) - len(suffix)
+	for i, name := range suffix {
+		if path[offset+i] != name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupParquetColumn finds the leaf column extracted for a column name, which may
+// name a top-level column, a dotted nested column, or a list column.
+func lookupParquetColumn(schema *parquet.Schema, column string) (parquet.LeafColumn, bool) {
+	candidates := [][]string{{column}}
+	if strings.Contains(column, ".") {
+		candidates = append(candidates, strings.Split(column, "."))
+	}
+
+	for _, candidate := range candidates {
+		if leaf, ok := schema.Lookup(candidate...); ok {
+			return leaf, true
+		}
+		for _, suffix := range parquetListSuffixes {
+			if leaf, ok := schema.Lookup(append(candidate[:len(candidate):len(candidate)], suffix...)...); ok {
+				return leaf, true
+			}
+		}
+	}
+
+	return parquet.LeafColumn{}, false
+}
+
+// parquetDataType maps the physical and logical types of a Parquet column to a Croissant data type.
+func parquetDataType(typ parquet.Type) string {
+	if logical := typ.LogicalType(); logical != nil {
+		switch {
+		case logical.Date != nil:
+			return VT_scDate
+		case logical.Timestamp != nil:
+			return VT_scDateT
+		case logical.Integer != nil:
+			return VT_scInt
+		case logical.Decimal != nil:
+			return VT_scFloat
+		case logical.UTF8 != nil, logical.Enum != nil, logical.Json != nil, logical.UUID != nil, logical.Time != nil:
+			return VT_scText
+		}
+	}
+	if converted := typ.ConvertedType(); converted != nil {
+		switch *converted {
+		case deprecated.Date:
+			return VT_scDate
+		case deprecated.TimestampMillis, deprecated.TimestampMicros:
+			return VT_scDateT
+		case deprecated.Decimal:
+			return VT_scFloat
+		}
+	}
+
+	switch typ.Kind() {
+	case parquet.Boolean:
+		return VT_scBool
+	case parquet.Int32, parquet.Int64:
+		return VT_scInt
+	case parquet.Int96:
+		return VT_scDateT
+	case parquet.Float, parquet.Double:
+		return VT_scFloat
+	default:
+		return VT_scText
+	}
+}
+
+// openParquetFile opens Parquet content. Parquet needs random access to the
+// file footer, so content streamed out of an archive is buffered in memory.
+func openParquetFile(content io.Reader) (*parquet.File, error) {
+	var readerAt io.ReaderAt
+	var size int64
+	if file, ok := content.(*os.File); ok {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, CroissantError{Message: "failed to get file info", Value: err}
+		}
+		readerAt, size = file, info.Size()
+	} else {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return nil, CroissantError{Message: "failed to read Parquet content", Value: err}
+		}
+		readerAt, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	parquetFile, err := parquet.OpenFile(readerAt, size)
+	if err != nil {
+		return nil, CroissantError{Message: "failed to open Parquet file", Value: err}
+	}
+
+	return parquetFile, nil
+}
+
+// parquetSource yields the columns referenced by a set of fields, one row at a time.
+type parquetSource struct {
+	file    io.ReadCloser
+	reader  *parquet.Reader
+	columns map[string]parquet.LeafColumn // field key -> leaf column
+	rows    []parquet.Row
+	next    int
+	read    int
+	done    bool
+}
+
+// openParquetSource opens a Parquet distribution and maps each field to its column.
+func openParquetSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions) (*parquetSource, error) {
+	file, err := openDistributionContent(ctx, metadata, dist, options.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	parquetFile, err := openParquetFile(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	columns := make(map[string]parquet.LeafColumn, len(fields))
+	for _, field := range fields {
+		column := field.Source.Extract.Column
+		if column == "" {
+			_ = file.Close()
+			return nil, CroissantError{
+				Message: fmt.Sprintf("field %q must extract a column from Parquet distribution %q", FieldKey(field), dist.ID),
+			}
+		}
+		leaf, exists := lookupParquetColumn(parquetFile.Schema(), column)
+		if !exists {
+			_ = file.Close()
+			return nil, CroissantError{
+				Message: fmt.Sprintf("column not found in distribution %q", dist.ID),
+				Value:   column,
+			}
+		}
+		columns[FieldKey(field)] = leaf
+	}
+
+	return &parquetSource{
+		file:    file,
+		reader:  parquet.NewReader(parquetFile),
+		columns: columns,
+		rows:    make([]parquet.Row, 128),
+	}, nil
+}
+
+// Next returns the values of the next Parquet row.
+func (s *parquetSource) Next() (map[string]interface{}, error) {
+	if s.next >= s.read {
+		if s.done {
+			return nil, io.EOF
+		}
+		read, err := s.reader.ReadRows(s.rows)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, CroissantError{Message: "failed to read Parquet rows", Value: err}
+		}
+		s.next, s.read, s.done = 0, read, err != nil
+		if read == 0 {
+			return nil, io.EOF
+		}
+	}
+
+	row := s.rows[s.next]
+	s.next++
+
+	values := make(map[string]interface{}, len(s.columns))
+	for key, leaf := range s.columns {
+		var items []interface{}
+		var value interface{}
+		for _, parquetValue := range row {
+			if parquetValue.Column() != leaf.ColumnIndex {
+				continue
+			}
+			value = convertParquetValue(parquetValue, leaf.Node.Type())
+			// Null list entries, including those marking empty or missing lists, are skipped.
+			if value != nil {
+				items = append(items, value)
+			}
+		}
+		if leaf.MaxRepetitionLevel > 0 {
+			if items == nil {
+				items = []interface{}{}
+			}
+			values[key] = items
+		} else {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+// Close closes the underlying file.
+func (s *parquetSource) Close() error {
+	_ = s.reader.Close()
+	return s.file.Close()
+}
+
+// convertParquetValue converts a Parquet value to a Go value according to its column type.
+//
+//nolint:cyclop
+func convertParquetValue(value parquet.Value, typ parquet.Type) interface{} {
+	if value.IsNull() {
+		return nil
+	}
+
+	logical := typ.LogicalType()
+	converted := typ.ConvertedType()
+	switch {
+	case (logical != nil && logical.Date != nil) || (converted != nil && *converted == deprecated.Date):
+		return time.Unix(int64(value.Int32())*86400, 0).UTC()
+	case logical != nil && logical.Timestamp != nil:
+		return parquetTimestamp(value.Int64(), logical.Timestamp.Unit)
+	case converted != nil && *converted == deprecated.TimestampMillis:
+		return time.UnixMilli(value.Int64()).UTC()
+	case converted != nil && *converted == deprecated.TimestampMicros:
+		return time.UnixMicro(value.Int64()).UTC()
+	case logical != nil && logical.Decimal != nil:
+		return parquetDecimal(value, int(logical.Decimal.Scale))
+	}
+
+	switch value.Kind() {
+	case parquet.Boolean:
+		return value.Boolean()
+	case parquet.Int32:
+		return int64(value.Int32())
+	case parquet.Int64:
+		return value.Int64()
+	case parquet.Int96:
+		// Legacy timestamps: nanoseconds within the day, then the Julian day.
+		int96 := value.Int96()
+		nanos := int64(uint64(int96[1])<<32 | uint64(int96[0])) //nolint:gosec
+		days := int64(int96[2]) - 2440588                       // Julian day of the Unix epoch
+		return time.Unix(days*86400, nanos).UTC()
+	case parquet.Float:
+		return float64(value.Float())
+	case parquet.Double:
+		return value.Double()
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		isText := (logical != nil && (logical.UTF8 != nil || logical.Enum != nil || logical.Json != nil)) ||
+			(converted != nil && (*converted == deprecated.UTF8 || *converted == deprecated.Enum || *converted == deprecated.Json))
+		if isText {
+			return string(value.ByteArray())
+		}
+		return bytes.Clone(value.ByteArray())
+	default:
+		return nil
+	}
+}
+
+// parquetTimestamp converts a Parquet timestamp in the given unit to a time.
+func parquetTimestamp(timestamp int64, unit format.TimeUnit) time.Time {
+	switch {
+	case unit.Millis != nil:
+		return time.UnixMilli(timestamp).UTC()
+	case unit.Micros != nil:
+		return time.UnixMicro(timestamp).UTC()
+	default:
+		return time.Unix(0, timestamp).UTC()
+	}
+}
+
+// parquetDecimal converts a Parquet decimal to a float.
+func parquetDecimal(value parquet.Value, scale int) float64 {
+	unscaled := new(big.Int)
+	switch value.Kind() {
+	case parquet.Int32:
+		unscaled.SetInt64(int64(value.Int32()))
+	case parquet.Int64:
+		unscaled.SetInt64(value.Int64())
+	default:
+		// Big-endian two's complement.
+		data := value.ByteArray()
+		unscaled.SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+	}
+
+	result, _ := new(big.Float).Quo(new(big.Float).SetInt(unscaled), big.NewFloat(math.Pow10(scale))).Float64()
+	return result
+}
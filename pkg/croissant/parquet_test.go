@@ -0,0 +1,109 @@
+// File: pkg/croissant/parquet_test.go
+package croissant
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type parquetTestRow struct {
+	Name    string    `parquet:"name"`
+	Age     int64     `parquet:"age"`
+	Score   *float64  `parquet:"score,optional"`
+	Born    int32     `parquet:"born,date"`
+	Updated time.Time `parquet:"updated,timestamp(millisecond)"`
+	Active  bool      `parquet:"active"`
+	Tags    []string  `parquet:"tags,list"`
+}
+
+func writeTestParquet(t *testing.T, parquetPath string) {
+	t.Helper()
+	file, err := os.Create(parquetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	score := 0.5
+	rows := []parquetTestRow{
+		{Name: "Alice", Age: 30, Score: &score, Born: 19000, Updated: time.UnixMilli(1700000000000).UTC(), Active: true, Tags: []string{"a", "b"}},
+		{Name: "Bob", Age: 25, Born: 19001, Updated: time.UnixMilli(1700000001000).UTC(), Tags: []string{}},
+	}
+	writer := parquet.NewGenericWriter[parquetTestRow](file)
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetParquetColumns(t *testing.T) {
+	parquetPath := filepath.Join(t.TempDir(), "people.parquet")
+	writeTestParquet(t, parquetPath)
+
+	columns, err := GetParquetColumns(parquetPath)
+	if err != nil {
+		t.Fatalf("GetParquetColumns: %v", err)
+	}
+
+	want := []ParquetColumn{
+		{Name: "name", DataType: VT_scText},
+		{Name: "age", DataType: VT_scInt},
+		{Name: "score", DataType: VT_scFloat},
+		{Name: "born", DataType: VT_scDate},
+		{Name: "updated", DataType: VT_scDateT},
+		{Name: "active", DataType: VT_scBool},
+		{Name: "tags", DataType: VT_scText, Repeated: true},
+	}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %+v, want %+v", columns, want)
+	}
+}
+
+func TestGenerateMetadataFromParquet(t *testing.T) {
+	dir := t.TempDir()
+	parquetPath := filepath.Join(dir, "people.parquet")
+	writeTestParquet(t, parquetPath)
+
+	metadata, err := GenerateMetadataWithValidation(parquetPath, "")
+	if err != nil {
+		t.Fatalf("GenerateMetadataWithValidation: %v", err)
+	}
+	if metadata.HasErrors() {
+		t.Fatalf("generated metadata has errors: %s", metadata.Report())
+	}
+	if got := metadata.Distributions[0].EncodingFormat; got != "application/x-parquet" {
+		t.Errorf("encodingFormat = %q, want application/x-parquet", got)
+	}
+
+	var records []Record
+	for record, err := range RecordsWithOptions(context.Background(), metadata.Metadata, "main", RecordOptions{BaseDir: dir}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	want := []Record{
+		{
+			"main/name": "Alice", "main/age": int64(30), "main/score": 0.5,
+			"main/born": time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC), "main/updated": time.UnixMilli(1700000000000).UTC(),
+			"main/active": true, "main/tags": []interface{}{"a", "b"},
+		},
+		{
+			"main/name": "Bob", "main/age": int64(25), "main/score": nil,
+			"main/born": time.Date(2022, 1, 9, 0, 0, 0, 0, time.UTC), "main/updated": time.UnixMilli(1700000001000).UTC(),
+			"main/active": false, "main/tags": []interface{}{},
+		},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+}
@@ -0,0 +1,203 @@
+// profile.go
+// Infers the data type of each CSV column by streaming over all of its values.
+package croissant
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ProfileOptions represents options for profiling the columns of a CSV file.
+type ProfileOptions struct {
+	// Values counted as nulls rather than typed, compared after trimming whitespace.
+	NullValues []string
+}
+
+// DefaultProfileOptions returns the default profile options, treating empty values,
+// "NA", "N/A", "NaN", "null", "NULL" and "None" as nulls.
+func DefaultProfileOptions() ProfileOptions {
+	return ProfileOptions{
+		NullValues: []string{"", "NA", "N/A", "NaN", "null", "NULL", "None"},
+	}
+}
+
+// ColumnProfile summarizes all the values of a column.
+type ColumnProfile struct {
+	// Name of the column, from the CSV header.
+	Name string
+	// Narrowest data type consistent with every non-null value.
+	DataType string
+	// Number of non-null values.
+	Values int
+	// Number of null values, including missing cells of short rows.
+	Nulls int
+}
+
+// HasNulls returns true if the column has null values.
+func (p ColumnProfile) HasNulls() bool {
+	return p.Nulls > 0
+}
+
+// Data types a value can be read as, as bits of a columnProfiler mask.
+const (
+	profileBoolean = 1 << iota
+	profileInteger
+	profileNumber
+	profileDateTime
+	profileURL
+)
+
+// profileDataTypes are the data types of the mask bits, from the narrowest to the widest.
+var profileDataTypes = []struct { //nolint:gochecknoglobals
+	bit      int
+	dataType string
+}{
+	{profileBoolean, VT_scBool},
+	{profileInteger, VT_scInt},
+	{profileNumber, VT_scNum},
+	{profileDateTime, VT_scDateT},
+	{profileURL, VT_scURL},
+}
+
+// columnProfiler accumulates the profile of a column one value at a time, in constant memory.
+type columnProfiler struct {
+	profile ColumnProfile
+	// Data types consistent with every value seen so far.
+	mask int
+}
+
+// add profiles a value of the column.
+func (c *columnProfiler) add(value string, nulls []string) {
+	value = strings.TrimSpace(value)
+	if slices.Contains(nulls, value) {
+		c.profile.Nulls++
+		return
+	}
+
+	c.profile.Values++
+	if c.mask != 0 {
+		c.mask &= profileValueTypes(value)
+	}
+}
+
+// result returns the profile of the column. Columns without values are text.
+func (c *columnProfiler) result() ColumnProfile {
+	profile := c.profile
+	profile.DataType = VT_scText
+	if profile.Values == 0 {
+		return profile
+	}
+	for _, candidate := range profileDataTypes {
+		if c.mask&candidate.bit != 0 {
+			profile.DataType = candidate.dataType
+			break
+		}
+	}
+
+	return profile
+}
+
+// profileValueTypes returns the data types a value can be read as. Booleans are
+// true, false, 0 and 1, so that Boolean ⊂ Integer ⊂ Number: a column of zeros and
+// ones is a Boolean column, which widens to Integer with any other integer, and to
+// Number with any decimal.
+func profileValueTypes(value string) int {
+	mask := 0
+	switch strings.ToLower(value) {
+	case "true", "false":
+		return profileBoolean
+	case "0", "1":
+		mask |= profileBoolean
+	}
+
+	if _, err := parseInteger(value); err == nil {
+		mask |= profileInteger | profileNumber
+	} else if _, err := parseFloat(value); err == nil {
+		mask |= profileNumber
+	}
+	if _, err := parseDate(value); err == nil {
+		mask |= profileDateTime
+	}
+	// Only web URLs are inferred, as many other values parse as URLs
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		if _, err := url.ParseRequestURI(value); err == nil {
+			mask |= profileURL
+		}
+	}
+
+	return mask
+}
+
+// ProfileCSV reads every row of a CSV file and profiles each of its columns.
+func ProfileCSV(csvPath string) ([]ColumnProfile, error) {
+	return ProfileCSVWithOptions(csvPath, DefaultProfileOptions())
+}
+
+// ProfileCSVWithOptions reads every row of a CSV file and profiles each of its columns.
+// Files with a ".tsv" extension are tab-separated.
+//
+// The file is streamed, so memory does not grow with its number of rows. The data type
+// of a column is the narrowest one consistent with all its non-null values, in the
+// order sc:Boolean, sc:Integer, sc:Number, sc:DateTime and sc:URL, or else sc:Text.
+// A single decimal value in an integer column makes it sc:Number, wherever it is.
+func ProfileCSVWithOptions(csvPath string, options ProfileOptions) ([]ColumnProfile, error) {
+	file, err := os.Open(filepath.Clean(csvPath))
+	if err != nil {
+		return nil, CroissantError{Message: "failed to open CSV file", Value: err}
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	if strings.EqualFold(filepath.Ext(csvPath), ".tsv") {
+		reader.Comma = '\t'
+	}
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, CroissantError{Message: "failed to read CSV headers", Value: err}
+	}
+
+	profilers := make([]columnProfiler, len(headers))
+	for i, header := range headers {
+		profilers[i] = columnProfiler{
+			profile: ColumnProfile{Name: strings.TrimSpace(header)},
+			mask:    profileBoolean | profileInteger | profileNumber | profileDateTime | profileURL,
+		}
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, CroissantError{Message: fmt.Sprintf("failed to read CSV row %d", row), Value: err}
+		}
+
+		for i := range profilers {
+			value := ""
+			if i < len(record) {
+				value = record[i]
+			}
+			profilers[i].add(value, options.NullValues)
+		}
+	}
+
+	profiles := make([]ColumnProfile, len(profilers))
+	for i := range profilers {
+		profiles[i] = profilers[i].result()
+	}
+
+	return profiles, nil
+}
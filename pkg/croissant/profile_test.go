@@ -0,0 +1,72 @@
+// File: pkg/croissant/profile_test.go
+package croissant
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProfileCSV(t *testing.T) {
+	var content strings.Builder
+	content.WriteString("id,flag,score,amount,when,homepage,note,empty\n")
+	for i := range 10000 {
+		amount := fmt.Sprint(i)
+		if i == 9999 {
+			amount = "3.5"
+		}
+		score := fmt.Sprint(i % 2)
+		if i == 5000 {
+			score = "7"
+		}
+		flag := "true"
+		if i%3 == 0 {
+			flag = "NA"
+		}
+		fmt.Fprintf(&content, "%d,%s,%s,%s,2024-01-%02d,https://example.com/%d,note %d,\n", i, flag, score, amount, i%28+1, i, i)
+	}
+	content.WriteString("10000,false\n")
+	csvPath := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(csvPath, []byte(content.String()), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, err := ProfileCSV(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ColumnProfile{
+		{Name: "id", DataType: VT_scInt, Values: 10001},
+		{Name: "flag", DataType: VT_scBool, Values: 6667, Nulls: 3334},
+		{Name: "score", DataType: VT_scInt, Values: 10000, Nulls: 1},
+		{Name: "amount", DataType: VT_scNum, Values: 10000, Nulls: 1},
+		{Name: "when", DataType: VT_scDateT, Values: 10000, Nulls: 1},
+		{Name: "homepage", DataType: VT_scURL, Values: 10000, Nulls: 1},
+		{Name: "note", DataType: VT_scText, Values: 10000, Nulls: 1},
+		{Name: "empty", DataType: VT_scText, Nulls: 10001},
+	}
+	if !reflect.DeepEqual(profiles, want) {
+		t.Errorf("profiles =\n%+v\nwant\n%+v", profiles, want)
+	}
+}
+
+func TestProfileValueTypes(t *testing.T) {
+	columns := map[string][]string{
+		VT_scBool: {"0", "1", "true", "FALSE"},
+		VT_scInt:  {"0", "1", "2"},
+		VT_scNum:  {"1", "2.5", "1e3"},
+		VT_scText: {"true", "2"},
+	}
+	for want, values := range columns {
+		profiler := columnProfiler{mask: profileBoolean | profileInteger | profileNumber | profileDateTime | profileURL}
+		for _, value := range values {
+			profiler.add(value, DefaultProfileOptions().NullValues)
+		}
+		if got := profiler.result().DataType; got != want {
+			t.Errorf("column %v: data type = %s, want %s", values, got, want)
+		}
+	}
+}
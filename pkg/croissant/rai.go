@@ -0,0 +1,189 @@
+// rai.go
+// Models and validates the Croissant Responsible AI (RAI) vocabulary.
+package croissant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RAIPrefix is the IRI of the Croissant RAI vocabulary, declared as "rai" in the context.
+const RAIPrefix = "http://mlcommons.org/croissant/RAI/"
+
+// TextList represents one or many text values.
+type TextList []string
+
+// UnmarshalJSON implements custom JSON unmarshaling for TextList.
+//
+// Accepts:
+//   - "property": "..."
+//   - "property": ["...", "..."]
+func (t *TextList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = []string{single}
+
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err == nil {
+		*t = multi
+
+		return nil
+	}
+
+	return CroissantError{
+		Message: "TextList: cannot unmarshal",
+		Value:   string(data),
+	}
+}
+
+// MarshalJSON implements custom JSON marshaling for TextList.
+func (t TextList) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// RAI holds the properties of the Croissant Responsible AI extension, which describe how
+// a dataset was collected, annotated and may be used. They are part of Metadata.
+type RAI struct {
+	// Description of the data collection process.
+	DataCollection string `json:"rai:dataCollection,omitempty"`
+	// Types of data collection, e.g. "Surveys", "Web Scraping" or "Manual Human Curation".
+	DataCollectionType TextList `json:"rai:dataCollectionType,omitempty"`
+	// Description of missing data in the collected data.
+	DataCollectionMissingData string `json:"rai:dataCollectionMissingData,omitempty"`
+	// Description of the raw data, i.e. the source of the data.
+	DataCollectionRawData string `json:"rai:dataCollectionRawData,omitempty"`
+	// Start and end dates or datetimes of the data collection.
+	DataCollectionTimeframe TextList `json:"rai:dataCollectionTimeframe,omitempty"`
+	// Description of the data imputation process, if any.
+	DataImputationProtocol string `json:"rai:dataImputationProtocol,omitempty"`
+	// Description of the data manipulation process, if any.
+	DataManipulationProtocol string `json:"rai:dataManipulationProtocol,omitempty"`
+	// Steps taken to preprocess the data.
+	DataPreprocessingProtocol TextList `json:"rai:dataPreprocessingProtocol,omitempty"`
+	// Description of the annotation process.
+	DataAnnotationProtocol string `json:"rai:dataAnnotationProtocol,omitempty"`
+	// Platforms, tools or libraries used to annotate the data.
+	DataAnnotationPlatform TextList `json:"rai:dataAnnotationPlatform,omitempty"`
+	// Analysis of the annotations, e.g. of the agreement between annotators.
+	DataAnnotationAnalysis TextList `json:"rai:dataAnnotationAnalysis,omitempty"`
+	// Number of human labels per dataset item.
+	AnnotationsPerItem string `json:"rai:annotationsPerItem,omitempty"`
+	// Demographic specifications of the annotators.
+	AnnotatorDemographics TextList `json:"rai:annotatorDemographics,omitempty"`
+	// Automatic annotation tools used, e.g. models or heuristics.
+	MachineAnnotationTools TextList `json:"rai:machineAnnotationTools,omitempty"`
+	// Known biases of the data.
+	DataBiases TextList `json:"rai:dataBiases,omitempty"`
+	// Intended and unintended use cases of the data.
+	DataUseCases TextList `json:"rai:dataUseCases,omitempty"`
+	// Known limitations of the data.
+	DataLimitations TextList `json:"rai:dataLimitations,omitempty"`
+	// Social impact of the data.
+	DataSocialImpact string `json:"rai:dataSocialImpact,omitempty"`
+	// Personal and sensitive information the data contains, e.g. gender or geolocation.
+	PersonalSensitiveInformation TextList `json:"rai:personalSensitiveInformation,omitempty"`
+	// Versioning, updating and deprecation plan of the data.
+	DataReleaseMaintenancePlan string `json:"rai:dataReleaseMaintenancePlan,omitempty"`
+}
+
+// raiProperty is a property of the RAI vocabulary with its values.
+type raiProperty struct {
+	name   string
+	values []string
+	// Recommended properties are reported as missing in strict RAI mode.
+	recommended bool
+}
+
+// properties returns the RAI properties in vocabulary order.
+func (r RAI) properties() []raiProperty {
+	text := func(value string) []string {
+		if value == "" {
+			return nil
+		}
+		return []string{value}
+	}
+
+	return []raiProperty{
+		{"dataCollection", text(r.DataCollection), true},
+		{"dataCollectionType", r.DataCollectionType, false},
+		{"dataCollectionMissingData", text(r.DataCollectionMissingData), false},
+		{"dataCollectionRawData", text(r.DataCollectionRawData), false},
+		{"dataCollectionTimeframe", r.DataCollectionTimeframe, false},
+		{"dataImputationProtocol", text(r.DataImputationProtocol), false},
+		{"dataManipulationProtocol", text(r.DataManipulationProtocol), false},
+		{"dataPreprocessingProtocol", r.DataPreprocessingProtocol, false},
+		{"dataAnnotationProtocol", text(r.DataAnnotationProtocol), true},
+		{"dataAnnotationPlatform", r.DataAnnotationPlatform, false},
+		{"dataAnnotationAnalysis", r.DataAnnotationAnalysis, false},
+		{"annotationsPerItem", text(r.AnnotationsPerItem), false},
+		{"annotatorDemographics", r.AnnotatorDemographics, false},
+		{"machineAnnotationTools", r.MachineAnnotationTools, false},
+		{"dataBiases", r.DataBiases, true},
+		{"dataUseCases", r.DataUseCases, true},
+		{"dataLimitations", r.DataLimitations, true},
+		{"dataSocialImpact", text(r.DataSocialImpact), true},
+		{"personalSensitiveInformation", r.PersonalSensitiveInformation, true},
+		{"dataReleaseMaintenancePlan", text(r.DataReleaseMaintenancePlan), true},
+	}
+}
+
+// IsEmpty returns true if no RAI property is set.
+func (r RAI) IsEmpty() bool {
+	for _, property := range r.properties() {
+		if len(property.values) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// declaresRAIPrefix returns true if a context declares the "rai" prefix.
+func declaresRAIPrefix(context Context) bool {
+	return context.RAI != "" || context.Extra["rai"] != nil
+}
+
+// validateRAI validates the RAI properties of a dataset. With options.StrictRAI, recommended
+// RAI properties that are missing are reported as warnings.
+func validateRAI(node *MetadataNode, issues *Issues, options ValidationOptions) {
+	rai := node.metadata.RAI
+	if !rai.IsEmpty() && !declaresRAIPrefix(node.Context) {
+		issues.AddError(fmt.Sprintf("RAI properties are used, but the context does not declare the \"rai\" prefix as \"%s\".", RAIPrefix), node)
+	}
+
+	for _, property := range rai.properties() {
+		if len(property.values) == 0 {
+			if options.StrictRAI && property.recommended {
+				issues.AddWarning(fmt.Sprintf("Property \"%s%s\" is recommended, but does not exist.", RAIPrefix, property.name), node)
+			}
+			continue
+		}
+
+		for _, value := range property.values {
+			if strings.TrimSpace(value) == "" {
+				issues.AddWarning(fmt.Sprintf("Property \"%s%s\" has an empty value.", RAIPrefix, property.name), node)
+			}
+		}
+	}
+
+	timeframe := rai.DataCollectionTimeframe
+	if len(timeframe) > 2 {
+		issues.AddError(fmt.Sprintf("Property \"%sdataCollectionTimeframe\" must have a start and an end, but has %d values.", RAIPrefix, len(timeframe)), node)
+	}
+	for _, value := range timeframe {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if _, err := parseDate(value); err != nil {
+			issues.AddError(fmt.Sprintf("Property \"%sdataCollectionTimeframe\" value \"%s\" is not a valid date or datetime.", RAIPrefix, value), node)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// File: pkg/croissant/rai_test.go
+package croissant
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRAIProperties(t *testing.T) {
+	document := `{
+		"@context": {"@vocab": "https://schema.org/", "sc": "https://schema.org/", "responsibleAI": "http://mlcommons.org/croissant/RAI/"},
+		"@type": "sc:Dataset",
+		"name": "cities",
+		"responsibleAI:dataCollection": "Collected by hand.",
+		"responsibleAI:dataBiases": "Only European cities.",
+		"http://mlcommons.org/croissant/RAI/dataUseCases": ["Geocoding", "Teaching"]
+	}`
+	metadata, err := NewJSONLDProcessor().ParseCroissantMetadata([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.DataCollection != "Collected by hand." {
+		t.Errorf("DataCollection = %q", metadata.DataCollection)
+	}
+	if !reflect.DeepEqual(metadata.DataBiases, TextList{"Only European cities."}) {
+		t.Errorf("DataBiases = %v", metadata.DataBiases)
+	}
+	if !reflect.DeepEqual(metadata.DataUseCases, TextList{"Geocoding", "Teaching"}) {
+		t.Errorf("DataUseCases = %v", metadata.DataUseCases)
+	}
+	if len(metadata.Extra) != 0 {
+		t.Errorf("RAI properties should not be in Extra: %v", metadata.Extra)
+	}
+	if metadata.Context.RAI != RAIPrefix {
+		t.Errorf("Context.RAI = %q, want the rai prefix to be declared", metadata.Context.RAI)
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"rai:dataBiases":"Only European cities.","rai:dataUseCases":["Geocoding","Teaching"]`; !strings.Contains(string(encoded), want) {
+		t.Errorf("encoded metadata is missing %s: %s", want, encoded)
+	}
+}
+
+func TestValidateRAI(t *testing.T) {
+	issues, err := ValidateFile(filepath.Join("testdata/1.0/bad", "invalid_rai.jsonld"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := issues.Report()
+	for _, want := range []string{
+		`Property "http://mlcommons.org/croissant/RAI/dataCollectionTimeframe" value "last spring" is not a valid date or datetime.`,
+		`Property "http://mlcommons.org/croissant/RAI/personalSensitiveInformation" has an empty value.`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "is recommended, but does not exist") {
+		t.Errorf("missing RAI properties should only be reported in strict RAI mode:\n%s", report)
+	}
+
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt))
+	metadata.DataBiases = TextList{"None known."}
+	options := DefaultValidationOptions()
+	options.StrictRAI = true
+	report = ValidateMetadataWithOptions(metadata, options).Report()
+	for _, want := range []string{
+		`RAI properties are used, but the context does not declare the "rai" prefix`,
+		`Property "http://mlcommons.org/croissant/RAI/dataCollection" is recommended, but does not exist.`,
+		`Property "http://mlcommons.org/croissant/RAI/dataLimitations" is recommended, but does not exist.`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "RAI/dataBiases") {
+		t.Errorf("dataBiases is set and should not be reported:\n%s", report)
+	}
+}
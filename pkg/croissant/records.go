@@ -0,0 +1,511 @@
+// records.go
+// Materializes the rows described by a RecordSet.
+package croissant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// Record is a single materialized row of a RecordSet.
+// Values are keyed by field ID (or field name when the field has no ID)
+// and converted to Go values according to the field's DataType.
+//
+// Fields with subfields are nested Records keyed by subfield ID, and repeated
+// fields are []interface{} slices (of Records, for repeated fields with subfields).
+//
+// Fields that reference a field of another record set (through references or
+// parentField) act as foreign keys: the referenced Record is nested under the
+// ID of the referenced record set.
+type Record map[string]interface{}
+
+// MarshalJSON encodes a record as a JSON object, with URL values as strings.
+func (r Record) MarshalJSON() ([]byte, error) {
+	values := make(map[string]interface{}, len(r))
+	for key, value := range r {
+		values[key] = jsonRecordValue(value)
+	}
+
+	return json.Marshal(values)
+}
+
+// jsonRecordValue replaces values that do not encode to JSON as their text form.
+func jsonRecordValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case *url.URL:
+		return typed.String()
+	case []interface{}:
+		values := make([]interface{}, len(typed))
+		for i, item := range typed {
+			values[i] = jsonRecordValue(item)
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// RecordOptions represents options for reading records.
+type RecordOptions struct {
+	// Directory that relative distribution content URLs are resolved against.
+	// Usually the directory containing the metadata file.
+	// Defaults to the current working directory.
+	BaseDir string
+
+	// Disables joining referenced record sets, for checks of a record set's own values.
+	noJoins bool
+}
+
+// DefaultRecordOptions returns default record reading options.
+func DefaultRecordOptions() RecordOptions {
+	return RecordOptions{
+		BaseDir: "",
+	}
+}
+
+// RecordError describes a value of a row that could not be materialized.
+// The row is still yielded, with the offending field left unset.
+type RecordError struct {
+	// ID of the record set being read.
+	RecordSet string
+	// 1-based index of the row within the record set.
+	Row int
+	// Key of the field whose value failed.
+	Field string
+	// Raw value as extracted from the source.
+	Value interface{}
+	// Underlying conversion or extraction error.
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record set %q, row %d, field %q: %v (value %v)", e.RecordSet, e.Row, e.Field, e.Err, e.Value)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Records returns an iterator over the records of a record set.
+// Relative content URLs are resolved against the current working directory.
+//
+// Example:
+//
+//	for record, err := range croissant.Records(ctx, *metadata, "main") {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(record["main/transaction_id"])
+//	}
+func Records(ctx context.Context, metadata Metadata, recordSetID string) iter.Seq2[Record, error] {
+	return RecordsWithOptions(ctx, metadata, recordSetID, DefaultRecordOptions())
+}
+
+// RecordsWithOptions returns an iterator over the records of a record set with specific options.
+//
+// Values that cannot be converted to their field's DataType, and foreign keys
+// that match no referenced record, are reported as a *RecordError alongside the
+// partially populated record, and iteration continues.
+// Any other error (missing files, unsupported formats, cancelled context) is
+// yielded once with a nil record and ends the iteration.
+func RecordsWithOptions(ctx context.Context, metadata Metadata, recordSetID string, options RecordOptions) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		reader, err := newRecordReader(ctx, metadata, recordSetID, options)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer reader.Close()
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			record, recordErrs, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(record, joinRecordErrors(recordErrs)) {
+				return
+			}
+		}
+	}
+}
+
+// joinRecordErrors combines the row-level errors of a record into a single error.
+func joinRecordErrors(recordErrs []*RecordError) error {
+	switch len(recordErrs) {
+	case 0:
+		return nil
+	case 1:
+		return recordErrs[0]
+	default:
+		errs := make([]error, len(recordErrs))
+		for i, recordErr := range recordErrs {
+			errs[i] = recordErr
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// recordSource yields raw, unconverted field values for the rows of a record set.
+type recordSource interface {
+	// Next returns the raw values of the next row keyed by field key, or io.EOF.
+	Next() (map[string]interface{}, error)
+	Close() error
+}
+
+// recordReader combines the sources of a record set and converts their values.
+type recordReader struct {
+	recordSet    *RecordSet
+	fields       []Field
+	leaves       []Field
+	transformers map[string]*valueTransformer
+	sources      []recordSource
+	joins        []*recordJoin
+	row          int
+}
+
+// newRecordReader opens every distribution referenced by the fields of a record set,
+// and loads the record sets it references.
+func newRecordReader(ctx context.Context, metadata Metadata, recordSetID string, options RecordOptions) (*recordReader, error) {
+	return openRecordReader(ctx, metadata, recordSetID, options, make(map[string]bool))
+}
+
+// openRecordReader opens a record reader. Record sets in visiting are already being
+// read by a parent reader and are not joined again.
+func openRecordReader(ctx context.Context, metadata Metadata, recordSetID string, options RecordOptions, visiting map[string]bool) (*recordReader, error) {
+	recordSet := FindRecordSet(metadata, recordSetID)
+	if recordSet == nil {
+		return nil, CroissantError{Message: "record set not found", Value: recordSetID}
+	}
+
+	var joins []*recordJoin
+	if !options.noJoins {
+		var err error
+		if joins, err = newRecordJoins(metadata, recordSet, visiting); err != nil {
+			return nil, err
+		}
+	}
+	if len(joins) > 0 {
+		visiting[recordSetKey(*recordSet)] = true
+		defer delete(visiting, recordSetKey(*recordSet))
+		for _, join := range joins {
+			if err := join.load(ctx, metadata, options, visiting); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Group fields by the distribution they are extracted from, keeping declaration order.
+	// Record sets with inline data read every field from it.
+	leaves := leafFields(recordSet.Fields)
+	inline := len(recordSet.Data) > 0
+	var sourceIDs []string
+	fieldsBySource := make(map[string][]Field)
+	for _, field := range leaves {
+		if inline {
+			continue
+		}
+		sourceID := fieldSourceID(field)
+		if sourceID == "" {
+			return nil, CroissantError{Message: "field has no source", Value: FieldKey(field)}
+		}
+		if _, exists := fieldsBySource[sourceID]; !exists {
+			sourceIDs = append(sourceIDs, sourceID)
+		}
+		fieldsBySource[sourceID] = append(fieldsBySource[sourceID], field)
+	}
+
+	reader := &recordReader{
+		recordSet:    recordSet,
+		fields:       recordSet.Fields,
+		leaves:       leaves,
+		transformers: make(map[string]*valueTransformer, len(leaves)),
+		joins:        joins,
+	}
+	for _, field := range leaves {
+		transformer, err := newValueTransformer(field)
+		if err != nil {
+			return nil, err
+		}
+		reader.transformers[FieldKey(field)] = transformer
+	}
+	if inline {
+		reader.sources = append(reader.sources, newInlineSource(recordSet, leaves))
+	}
+
+	for _, sourceID := range sourceIDs {
+		dist := findDistribution(metadata, sourceID)
+		if dist == nil {
+			return nil, CroissantError{Message: "distribution not found", Value: sourceID}
+		}
+
+		source, err := openRecordSource(ctx, metadata, *dist, fieldsBySource[sourceID], options)
+		if err != nil {
+			reader.Close()
+			return nil, err
+		}
+		reader.sources = append(reader.sources, source)
+	}
+
+	return reader, nil
+}
+
+// Next reads the next row from every source and converts it into a Record.
+// Sources are read in lockstep; they must all yield the same number of rows.
+func (r *recordReader) Next() (Record, []*RecordError, error) {
+	raw := make(map[string]interface{}, len(r.fields))
+	finished := 0
+	for _, source := range r.sources {
+		values, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			finished++
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, value := range values {
+			raw[key] = value
+		}
+	}
+
+	if finished == len(r.sources) {
+		return nil, nil, io.EOF
+	}
+	if finished > 0 {
+		return nil, nil, CroissantError{
+			Message: "sources of record set have different numbers of rows",
+			Value:   r.recordSet.ID,
+		}
+	}
+
+	r.row++
+	values := make(map[string]interface{}, len(r.leaves))
+	var recordErrs []*RecordError
+	for _, field := range r.leaves {
+		key := FieldKey(field)
+		value, err := r.transformers[key].Apply(raw[key])
+		if err == nil {
+			value, err = convertValue(value, field.DataType)
+		}
+		if err != nil {
+			recordErrs = append(recordErrs, r.recordError(key, raw[key], err))
+			continue
+		}
+		values[key] = value
+	}
+
+	record := make(Record, len(r.fields))
+	for _, field := range r.fields {
+		key := FieldKey(field)
+		value, exists, err := nestValue(field, values)
+		if err != nil {
+			recordErrs = append(recordErrs, r.recordError(key, nil, err))
+			continue
+		}
+		if exists {
+			record[key] = value
+		}
+	}
+
+	for _, join := range r.joins {
+		if recordErr := join.apply(record, recordSetKey(*r.recordSet), r.row); recordErr != nil {
+			recordErrs = append(recordErrs, recordErr)
+		}
+	}
+
+	return record, recordErrs, nil
+}
+
+// recordError reports a value of the current row that could not be materialized.
+func (r *recordReader) recordError(field string, value interface{}, err error) *RecordError {
+	return &RecordError{
+		RecordSet: recordSetKey(*r.recordSet),
+		Row:       r.row,
+		Field:     field,
+		Value:     value,
+		Err:       err,
+	}
+}
+
+// Close releases every open source.
+func (r *recordReader) Close() {
+	for _, source := range r.sources {
+		_ = source.Close()
+	}
+}
+
+// openRecordSource opens a reader for a distribution based on its type and encoding format.
+func openRecordSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions) (recordSource, error) {
+	if dist.Type == "cr:FileSet" || usesFileProperties(fields) {
+		return openFileSource(ctx, metadata, dist, fields, options)
+	}
+
+	// Fields of a FileObject may combine file properties with extractions from its content.
+	var propertyFields, contentFields []Field
+	for _, field := range fields {
+		if field.Source.Extract.FileProperty != "" {
+			propertyFields = append(propertyFields, field)
+		} else {
+			contentFields = append(contentFields, field)
+		}
+	}
+
+	source, err := openContentSource(ctx, metadata, dist, contentFields, options)
+	if err != nil || len(propertyFields) == 0 {
+		return source, err
+	}
+	withProperties, err := withFileObjectProperties(source, dist, propertyFields)
+	if err != nil {
+		_ = source.Close()
+		return nil, err
+	}
+
+	return withProperties, nil
+}
+
+// openContentSource opens a reader for the content of a FileObject based on its encoding format.
+func openContentSource(ctx context.Context, metadata Metadata, dist Distribution, fields []Field, options RecordOptions) (recordSource, error) {
+	if isParquetEncodingFormat(dist.EncodingFormat) {
+		return openParquetSource(ctx, metadata, dist, fields, options)
+	}
+
+	switch dist.EncodingFormat {
+	case "text/csv":
+		return openCSVSource(ctx, metadata, dist, fields, options, ',')
+	case "text/tab-separated-values":
+		return openCSVSource(ctx, metadata, dist, fields, options, '\t')
+	case "application/json":
+		return openJSONSource(ctx, metadata, dist, fields, options)
+	case "application/jsonl", "application/x-ndjson":
+		return openJSONLinesSource(ctx, metadata, dist, fields, options)
+	default:
+		return nil, CroissantError{
+			Message: fmt.Sprintf("unsupported encoding format for distribution %q", dist.ID),
+			Value:   dist.EncodingFormat,
+		}
+	}
+}
+
+// resolveContentPath returns the local path of a distribution's content.
+func resolveContentPath(dist Distribution, baseDir string) (string, error) {
+	contentURL := strings.TrimPrefix(dist.ContentURL, "file://")
+	if contentURL == "" {
+		return "", CroissantError{Message: "distribution has no content URL", Value: dist.ID}
+	}
+	if !isLocalFile(contentURL) {
+		return "", CroissantError{Message: "remote distributions are not supported", Value: dist.ContentURL}
+	}
+	if filepath.IsAbs(contentURL) || baseDir == "" {
+		return filepath.Clean(contentURL), nil
+	}
+
+	return filepath.Join(baseDir, contentURL), nil
+}
+
+// FindRecordSet looks up a record set by ID, falling back to its name.
+// Returns nil if the metadata has no such record set.
+func FindRecordSet(metadata Metadata, recordSetID string) *RecordSet {
+	for i := range metadata.RecordSets {
+		if metadata.RecordSets[i].ID == recordSetID {
+			return &metadata.RecordSets[i]
+		}
+	}
+	for i := range metadata.RecordSets {
+		if metadata.RecordSets[i].Name == recordSetID {
+			return &metadata.RecordSets[i]
+		}
+	}
+
+	return nil
+}
+
+// FindField looks up a field by ID in every record set, and returns it with its record set.
+// IDs of the form "recordSet/field" are also matched against record set and field names.
+// Returns nil values if the metadata has no such field.
+func FindField(metadata Metadata, fieldID string) (*RecordSet, *Field) {
+	for i := range metadata.RecordSets {
+		recordSet := &metadata.RecordSets[i]
+		for j := range recordSet.Fields {
+			if recordSet.Fields[j].ID == fieldID {
+				return recordSet, &recordSet.Fields[j]
+			}
+		}
+	}
+
+	recordSetName, fieldName, found := strings.Cut(fieldID, "/")
+	if !found {
+		return nil, nil
+	}
+	recordSet := FindRecordSet(metadata, recordSetName)
+	if recordSet == nil {
+		return nil, nil
+	}
+	for j := range recordSet.Fields {
+		if recordSet.Fields[j].Name == fieldName || recordSet.Fields[j].Name == fieldID {
+			return recordSet, &recordSet.Fields[j]
+		}
+	}
+
+	return nil, nil
+}
+
+// findDistribution looks up a distribution by ID, falling back to its name.
+func findDistribution(metadata Metadata, distributionID string) *Distribution {
+	for i := range metadata.Distributions {
+		if metadata.Distributions[i].ID == distributionID {
+			return &metadata.Distributions[i]
+		}
+	}
+	for i := range metadata.Distributions {
+		if metadata.Distributions[i].Name == distributionID {
+			return &metadata.Distributions[i]
+		}
+	}
+
+	return nil
+}
+
+// fieldSourceID returns the ID of the FileObject or FileSet a field is extracted from.
+func fieldSourceID(field Field) string {
+	if field.Source.FileObject.ID != "" {
+		return field.Source.FileObject.ID
+	}
+
+	return field.Source.FileSet.ID
+}
+
+// FieldKey returns the key under which a field's value is stored in a Record:
+// the field ID, or the field name when the field has no ID.
+func FieldKey(field Field) string {
+	if field.ID != "" {
+		return field.ID
+	}
+
+	return field.Name
+}
+
+// recordSetKey returns the identifier used to refer to a record set.
+func recordSetKey(recordSet RecordSet) string {
+	if recordSet.ID != "" {
+		return recordSet.ID
+	}
+
+	return recordSet.Name
+}
@@ -0,0 +1,141 @@
+// File: pkg/croissant/records_test.go
+package croissant
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// csvTestMetadata returns metadata describing a single CSV file with the given fields.
+func csvTestMetadata(fields ...Field) Metadata {
+	return Metadata{
+		Context: CreateDefaultContext(),
+		Type:    "sc:Dataset",
+		Name:    "test",
+		Distributions: []Distribution{
+			{
+				ID:             "data.csv",
+				Type:           "cr:FileObject",
+				Name:           "data.csv",
+				ContentURL:     "data.csv",
+				EncodingFormat: "text/csv",
+			},
+		},
+		RecordSets: []RecordSet{
+			{
+				ID:     "main",
+				Type:   "cr:RecordSet",
+				Name:   "main",
+				Fields: fields,
+			},
+		},
+	}
+}
+
+// csvTestField returns a field extracting a column from data.csv.
+func csvTestField(column string, dataType string) Field {
+	return Field{
+		ID:       "main/" + column,
+		Type:     "cr:Field",
+		Name:     column,
+		DataType: NewSingleDataType(dataType),
+		Source: FieldSource{
+			Extract:    Extract{Column: column},
+			FileObject: FileObject{ID: "data.csv"},
+		},
+	}
+}
+
+func writeTestFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordsCSV(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id,name,score,active\n1,alice,3.5,true\n2,bob,,false\n")
+
+	metadata := csvTestMetadata(
+		csvTestField("id", VT_scInt),
+		csvTestField("name", VT_scText),
+		csvTestField("score", VT_scFloat),
+		csvTestField("active", VT_scBool),
+	)
+
+	var records []Record
+	for record, err := range RecordsWithOptions(context.Background(), metadata, "main", RecordOptions{BaseDir: dir}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if got := records[0]["main/id"]; got != int64(1) {
+		t.Errorf("id = %#v, want int64(1)", got)
+	}
+	if got := records[0]["main/name"]; got != "alice" {
+		t.Errorf("name = %#v, want \"alice\"", got)
+	}
+	if got := records[0]["main/score"]; got != 3.5 {
+		t.Errorf("score = %#v, want 3.5", got)
+	}
+	if got := records[1]["main/score"]; got != nil {
+		t.Errorf("empty score = %#v, want nil", got)
+	}
+	if got := records[1]["main/active"]; got != false {
+		t.Errorf("active = %#v, want false", got)
+	}
+}
+
+func TestRecordsConversionError(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id\n1\nabc\n3\n")
+
+	metadata := csvTestMetadata(csvTestField("id", VT_scInt))
+
+	count := 0
+	var recordErr *RecordError
+	for record, err := range RecordsWithOptions(context.Background(), metadata, "main", RecordOptions{BaseDir: dir}) {
+		count++
+		if err != nil {
+			if !errors.As(err, &recordErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, exists := record["main/id"]; exists {
+				t.Errorf("failed field should be unset, got %#v", record["main/id"])
+			}
+		}
+	}
+
+	if count != 3 {
+		t.Errorf("got %d records, want 3", count)
+	}
+	if recordErr == nil || recordErr.Row != 2 || recordErr.Value != "abc" {
+		t.Errorf("unexpected record error: %#v", recordErr)
+	}
+}
+
+func TestRecordsMissingColumn(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "data.csv", "id\n1\n")
+
+	metadata := csvTestMetadata(csvTestField("missing", VT_scText))
+
+	for record, err := range RecordsWithOptions(context.Background(), metadata, "main", RecordOptions{BaseDir: dir}) {
+		if err == nil || record != nil {
+			t.Fatalf("expected a fatal error, got record %#v, err %v", record, err)
+		}
+	}
+}
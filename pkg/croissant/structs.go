@@ -18,15 +18,18 @@ type Field struct {
 	SubField    []Field       `json:"subField,omitempty"`
 	ParentField FieldRefSlice `json:"parentField,omitempty"`
 	References  FieldRefSlice `json:"references,omitempty"`
+	// Properties the struct does not model, e.g. "equivalentProperty", by name.
+	// They are kept when decoding and emitted when encoding.
+	Extra map[string]interface{} `json:"-"`
 }
 
 // FieldSource represents the source information for a field.
 type FieldSource struct {
-	Extract    Extract    `json:"extract,omitempty"`
-	FileObject FileObject `json:"fileObject,omitempty"`
-	FileSet    FileObject `json:"fileSet,omitempty"`
-	Transform  Transform  `json:"transform,omitempty"`
-	Format     string     `json:"format,omitempty"`
+	Extract    Extract        `json:"extract,omitempty"`
+	FileObject FileObject     `json:"fileObject,omitempty"`
+	FileSet    FileObject     `json:"fileSet,omitempty"`
+	Transform  TransformSlice `json:"transform,omitempty"`
+	Format     string         `json:"format,omitempty"`
 }
 
 // Extract represents the extraction information for a field source.
@@ -57,6 +60,18 @@ type FieldRef struct {
 	Field *KeyRef `json:"field,omitempty"`
 }
 
+// FieldID returns the ID of the referenced field, whether given directly or nested under "field".
+func (ref FieldRef) FieldID() string {
+	if ref.ID != "" {
+		return ref.ID
+	}
+	if ref.Field != nil {
+		return ref.Field.ID
+	}
+
+	return ""
+}
+
 // Parses ONE or MANY FieldRefs.
 type FieldRefSlice []FieldRef
 
@@ -73,7 +88,7 @@ func (ref *FieldRefSlice) UnmarshalJSON(data []byte) error {
 		Field *FieldRef `json:"field,omitempty"`
 	}
 	var singleNested NestedFieldRef
-	if err := json.Unmarshal(data, &singleNested); err == nil {
+	if err := json.Unmarshal(data, &singleNested); err == nil && singleNested.Field != nil {
 		*ref = []FieldRef{*singleNested.Field}
 
 		return nil
@@ -125,7 +140,7 @@ func (key RecordSetKey) MarshalJSON() ([]byte, error) {
 	case 1:
 		return json.Marshal(key[0])
 	default:
-		return json.Marshal(key)
+		return json.Marshal([]KeyRef(key))
 	}
 }
 
@@ -167,19 +182,26 @@ func (k RecordSetKey) GetKeyIDs() []string {
 }
 
 // MarshalJSON implements custom JSON marshaling for DataType.
+// An empty DataType is null, which JSON-LD processing drops.
 func (d DataType) MarshalJSON() ([]byte, error) {
 	switch len(d) {
 	case 0:
-		return []byte("{}"), nil
+		return []byte("null"), nil
 	case 1:
 		return json.Marshal(d[0])
 	default:
-		return json.Marshal(d)
+		return json.Marshal([]string(d))
 	}
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for DataType.
 func (d *DataType) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = nil
+
+		return nil
+	}
+
 	// Try to unmarshal as a single string first
 	var singleType string
 	if err := json.Unmarshal(data, &singleType); err == nil && singleType != "" {
@@ -244,6 +266,9 @@ type Distribution struct {
 	Includes string `json:"includes,omitempty"`
 	// A glob pattern of the files to exclude (FileSet).
 	Excludes string `json:"excludes,omitempty"`
+	// Properties the struct does not model, e.g. "sameAs", by name.
+	// They are kept when decoding and emitted when encoding.
+	Extra map[string]interface{} `json:"-"`
 }
 
 // RecordSet represents a record set in the Croissant metadata.
@@ -256,6 +281,9 @@ type RecordSet struct {
 	Fields      []Field                  `json:"field"`
 	Key         *RecordSetKey            `json:"key,omitempty"`
 	Data        []map[string]interface{} `json:"data,omitempty"`
+	// Properties the struct does not model, e.g. "examples", by name.
+	// They are kept when decoding and emitted when encoding.
+	Extra map[string]interface{} `json:"-"`
 }
 
 // Context represents the complete JSON-LD context for Croissant 1.0.
@@ -295,6 +323,8 @@ type Context struct {
 	Source        string          `json:"source"`
 	SubField      string          `json:"subField"`
 	Transform     string          `json:"transform"`
+	// Terms the struct does not model, e.g. "prov", by name.
+	Extra map[string]interface{} `json:"-"`
 }
 
 // DataContext represents the data field in the context.
@@ -336,11 +366,11 @@ type Metadata struct {
 	// Note that this is different from schema.org/citation, which is used to make a citation to another publication from this dataset.
 	CiteAs string `json:"citeAs,omitempty"`
 	// Creator(s) of the dataset.
-	Creator interface{} `json:"creator,omitempty"`
+	Creator Agents `json:"creator,omitempty"`
 	// Publisher(s) of the dataset.
-	Publisher interface{} `json:"publisher,omitempty"`
+	Publisher Agents `json:"publisher,omitempty"`
 	// A set of keywords associated with the dataset, either as free text, or a DefinedTerm with a formal definition.
-	Keywords []string `json:"keywords,omitempty"`
+	Keywords Keywords `json:"keywords,omitempty"`
 	// Set of FileObject and FileSet definitions that describe the raw files of the dataset.
 	Distributions []Distribution `json:"distribution"`
 	// Set of RecordSet definitions that describe the content of the dataset.
@@ -348,18 +378,71 @@ type Metadata struct {
 	// If true, dataset is non-static and may change over time.
 	// Distribution resources may not contain a checksum if they are expected to change.
 	IsLiveDataset bool `json:"isLiveDataset,omitempty"`
+	// Responsible AI properties, such as "rai:dataCollection" or "rai:dataBiases".
+	RAI
+	// Properties the struct does not model, e.g. "sameAs", "inLanguage" or "citation", by name.
+	// They are kept when decoding and emitted when encoding, so metadata can be edited without loss.
+	Extra map[string]interface{} `json:"-"`
 }
 
 // Transform represents a data transformation.
 type Transform struct {
-	Type      string `json:"@type"`
-	Regex     string `json:"regex,omitempty"`
-	Replace   string `json:"replace,omitempty"`
-	Format    string `json:"format,omitempty"`
-	JSONPath  string `json:"jsonPath,omitempty"`
+	Type string `json:"@type,omitempty"`
+	// A regular expression whose first capture group (or whole match) is kept.
+	// When combined with Replace, matches are substituted instead.
+	Regex string `json:"regex,omitempty"`
+	// A replacement, either for Regex matches or as "pattern/replacement".
+	Replace string `json:"replace,omitempty"`
+	// A format used to parse text values, e.g. a date format such as "%Y-%m-%d".
+	Format string `json:"format,omitempty"`
+	// A JSONPATH expression evaluated against JSON values.
+	JSONPath string `json:"jsonPath,omitempty"`
+	// A separator used to split a value into repeated values.
 	Separator string `json:"separator,omitempty"`
 }
 
+// TransformSlice parses ONE or MANY Transforms.
+// Transforms are applied in the order they are declared.
+type TransformSlice []Transform
+
+// UnmarshalJSON implements custom JSON unmarshaling for TransformSlice.
+//
+// Accepts:
+//   - "transform": { "regex": "..." }
+//   - "transform": [{ "regex": "..." }, { "format": "..." }...]
+func (t *TransformSlice) UnmarshalJSON(data []byte) error {
+	var single Transform
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = []Transform{single}
+
+		return nil
+	}
+
+	var multi []Transform
+	if err := json.Unmarshal(data, &multi); err == nil {
+		*t = multi
+
+		return nil
+	}
+
+	return CroissantError{
+		Message: "TransformSlice: cannot unmarshal",
+		Value:   string(data),
+	}
+}
+
+// MarshalJSON implements custom JSON marshaling for TransformSlice.
+func (t TransformSlice) MarshalJSON() ([]byte, error) {
+	switch len(t) {
+	case 0:
+		return []byte("{}"), nil
+	case 1:
+		return json.Marshal(t[0])
+	default:
+		return json.Marshal([]Transform(t))
+	}
+}
+
 // Source represents a more complete source definition.
 type Source struct {
 	Extract    *Extract    `json:"extract,omitempty"`
@@ -403,7 +486,7 @@ func NewArrayDataType(dataTypes ...string) DataType {
 func (fs FieldSource) ValidateSource() bool {
 	// If no source is configured, it's invalid unless it's a parent field with subfields
 	hasFileObject := fs.FileObject.ID != "" || fs.FileSet.ID != ""
-	hasExtract := fs.Extract.Column != "" || fs.Extract.JSONPath != "" || fs.Extract.FileProperty != "" || fs.Extract.Regex != ""
+	hasExtract := fs.Extract.Column != "" || fs.Extract.JSONPath != "" || IsValidFileProperty(fs.Extract.FileProperty) || fs.Extract.Regex != ""
 
 	// A valid source needs either a file object reference with extraction info, or other valid configurations
 	return hasFileObject && (hasExtract || fs.Format != "")
@@ -0,0 +1,276 @@
+// transform.go
+// Applies Extract and Transform operations to raw extracted values.
+package croissant
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// transformStep is a single compiled transformation applied to a scalar value.
+type transformStep func(value interface{}) (interface{}, error)
+
+// valueTransformer applies the transformations declared by a field's source, in order.
+type valueTransformer struct {
+	steps []transformStep
+}
+
+// newValueTransformer compiles the Extract regex/separator, the Transform list,
+// and the source format of a field into a sequence of steps.
+//
+// Within a Transform the operations are applied as: jsonPath, regex, replace,
+// separator, format. Separators split a value into repeated values; subsequent
+// steps are applied to each of them.
+func newValueTransformer(field Field) (*valueTransformer, error) {
+	transformer := &valueTransformer{}
+
+	transforms := make([]Transform, 0, len(field.Source.Transform)+2)
+	if field.Source.Extract.Regex != "" || field.Source.Extract.Separator != "" {
+		transforms = append(transforms, Transform{
+			Regex:     field.Source.Extract.Regex,
+			Separator: field.Source.Extract.Separator,
+		})
+	}
+	transforms = append(transforms, field.Source.Transform...)
+	if field.Source.Format != "" {
+		transforms = append(transforms, Transform{Format: field.Source.Format})
+	}
+
+	for _, transform := range transforms {
+		if err := transformer.compile(transform, field.DataType); err != nil {
+			return nil, CroissantError{
+				Message: fmt.Sprintf("invalid transform for field %q", FieldKey(field)),
+				Value:   err,
+			}
+		}
+	}
+
+	return transformer, nil
+}
+
+// compile appends the steps of one Transform.
+//
+//nolint:cyclop
+func (t *valueTransformer) compile(transform Transform, dataType DataType) error {
+	if transform.JSONPath != "" {
+		path, err := compileJSONPath(transform.JSONPath)
+		if err != nil {
+			return err
+		}
+		t.steps = append(t.steps, jsonPathStep(path))
+	}
+
+	switch {
+	case transform.Regex != "" && transform.Replace != "":
+		pattern, err := regexp.Compile(transform.Regex)
+		if err != nil {
+			return err
+		}
+		t.steps = append(t.steps, replaceStep(pattern, transform.Replace))
+	case transform.Regex != "":
+		pattern, err := regexp.Compile(transform.Regex)
+		if err != nil {
+			return err
+		}
+		t.steps = append(t.steps, regexStep(pattern))
+	case transform.Replace != "":
+		pattern, replacement, err := splitReplace(transform.Replace)
+		if err != nil {
+			return err
+		}
+		t.steps = append(t.steps, replaceStep(pattern, replacement))
+	}
+
+	if transform.Separator != "" {
+		t.steps = append(t.steps, separatorStep(transform.Separator))
+	}
+
+	// Formats of other fields are reported by ValidateFieldNode, and not applied
+	if transform.Format != "" && isDateType(dataType) {
+		t.steps = append(t.steps, dateFormatStep(strftimeToLayout(transform.Format)))
+	}
+
+	return nil
+}
+
+// Apply runs every step on a raw value. Nil values are left untouched.
+func (t *valueTransformer) Apply(value interface{}) (interface{}, error) {
+	var err error
+	for _, step := range t.steps {
+		value, err = applyStep(step, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// applyStep applies a step to a scalar, or to each element of repeated values.
+func applyStep(step transformStep, value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		results := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			result, err := applyStep(step, item)
+			if err != nil {
+				return nil, err
+			}
+			// Separators nested inside repeated values are flattened.
+			if items, ok := result.([]interface{}); ok {
+				results = append(results, items...)
+			} else {
+				results = append(results, result)
+			}
+		}
+		return results, nil
+	default:
+		return step(value)
+	}
+}
+
+// jsonPathStep evaluates a JSONPath against a value. Text values are parsed as JSON first.
+func jsonPathStep(path *jsonPath) transformStep {
+	return func(value interface{}) (interface{}, error) {
+		document := value
+		if str, ok := value.(string); ok {
+			parsed, err := decodeJSON(strings.NewReader(str))
+			if err != nil {
+				return nil, CroissantError{Message: "value is not valid JSON", Value: str}
+			}
+			document = parsed
+		}
+		return path.Extract(document), nil
+	}
+}
+
+// regexStep keeps the first capture group of a match, or the whole match if the
+// expression has no groups. Values that do not match are an error.
+func regexStep(pattern *regexp.Regexp) transformStep {
+	return func(value interface{}) (interface{}, error) {
+		str := stringifyValue(value)
+		match := pattern.FindStringSubmatch(str)
+		if match == nil {
+			return nil, CroissantError{Message: fmt.Sprintf("value does not match regex %q", pattern.String()), Value: str}
+		}
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		return match[0], nil
+	}
+}
+
+// replaceStep substitutes every match of a pattern.
+func replaceStep(pattern *regexp.Regexp, replacement string) transformStep {
+	replacement = backrefPattern.ReplaceAllString(replacement, "$${$1}")
+	return func(value interface{}) (interface{}, error) {
+		return pattern.ReplaceAllString(stringifyValue(value), replacement), nil
+	}
+}
+
+// separatorStep splits a value into repeated values.
+func separatorStep(separator string) transformStep {
+	return func(value interface{}) (interface{}, error) {
+		parts := strings.Split(stringifyValue(value), separator)
+		results := make([]interface{}, len(parts))
+		for i, part := range parts {
+			results[i] = strings.TrimSpace(part)
+		}
+		return results, nil
+	}
+}
+
+// dateFormatStep parses a value with a Go time layout.
+func dateFormatStep(layout string) transformStep {
+	return func(value interface{}) (interface{}, error) {
+		if parsed, ok := value.(time.Time); ok {
+			return parsed, nil
+		}
+		str := strings.TrimSpace(stringifyValue(value))
+		if str == "" {
+			return nil, nil
+		}
+		parsed, err := time.Parse(layout, str)
+		if err != nil {
+			return nil, CroissantError{Message: fmt.Sprintf("value does not match format %q", layout), Value: str}
+		}
+		return parsed, nil
+	}
+}
+
+// backrefPattern matches \1-style backreferences in replacement strings.
+var backrefPattern = regexp.MustCompile(`\\(\d+)`) //nolint:gochecknoglobals
+
+// splitReplace parses a "pattern/replacement" replace specification.
+// A slash may be escaped with a backslash to be part of the pattern.
+func splitReplace(spec string) (*regexp.Regexp, string, error) {
+	for i := 0; i < len(spec); i++ {
+		if spec[i] == '\\' {
+			i++
+			continue
+		}
+		if spec[i] == '/' {
+			pattern, err := regexp.Compile(strings.ReplaceAll(spec[:i], `\/`, "/"))
+			if err != nil {
+				return nil, "", err
+			}
+			return pattern, spec[i+1:], nil
+		}
+	}
+
+	return nil, "", CroissantError{Message: "replace must have the form \"pattern/replacement\"", Value: spec}
+}
+
+// strftimeToLayout converts a strftime-style format (as used by Croissant) to a Go time layout.
+// Formats without any % directive are assumed to already be Go layouts.
+func strftimeToLayout(format string) string {
+	if !strings.Contains(format, "%") {
+		return format
+	}
+
+	directives := map[byte]string{
+		'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'e': "_2",
+		'H': "15", 'I': "03", 'M': "04", 'S': "05", 'p': "PM",
+		'b': "Jan", 'B': "January", 'a': "Mon", 'A': "Monday",
+		'j': "002", 'z': "-0700", 'Z': "MST", 'f': "000000",
+		'F': "2006-01-02", 'T': "15:04:05", '%': "%",
+	}
+
+	var layout strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			if directive, ok := directives[format[i+1]]; ok {
+				layout.WriteString(directive)
+				i++
+				continue
+			}
+		}
+		layout.WriteByte(format[i])
+	}
+
+	return layout.String()
+}
+
+// isDateType returns true if a DataType is parsed into time.Time values.
+func isDateType(dataType DataType) bool {
+	valueType, _ := resolveValueType(dataType)
+	return valueType == VT_scDateT || valueType == VT_scDate
+}
+
+// stringifyValue returns the text form of a scalar value.
+func stringifyValue(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case []byte:
+		return string(typed)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,131 @@
+// File: pkg/croissant/transform_test.go
+package croissant
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValueTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		source   FieldSource
+		dataType string
+		in       interface{}
+		want     interface{}
+	}{
+		{
+			name:     "regex capture group",
+			source:   FieldSource{Transform: TransformSlice{{Regex: `^(train|val|test)2014/.*\.jpg$`}}},
+			dataType: VT_scText,
+			in:       "train2014/COCO_train2014_000000000003.jpg",
+			want:     "train",
+		},
+		{
+			name:     "regex without group",
+			source:   FieldSource{Transform: TransformSlice{{Regex: `\d+`}}},
+			dataType: VT_scText,
+			in:       "image_0042.png",
+			want:     "0042",
+		},
+		{
+			name:     "regex with replace",
+			source:   FieldSource{Transform: TransformSlice{{Regex: `(\w+)@(\w+)`, Replace: `\2:\1`}}},
+			dataType: VT_scText,
+			in:       "user@host",
+			want:     "host:user",
+		},
+		{
+			name:     "replace pattern/replacement",
+			source:   FieldSource{Transform: TransformSlice{{Replace: `_/ `}}},
+			dataType: VT_scText,
+			in:       "a_b_c",
+			want:     "a b c",
+		},
+		{
+			name:     "separator",
+			source:   FieldSource{Extract: Extract{Separator: ";"}},
+			dataType: VT_scText,
+			in:       "a; b;c",
+			want:     []interface{}{"a", "b", "c"},
+		},
+		{
+			name:     "date format",
+			source:   FieldSource{Transform: TransformSlice{{Format: "%d/%m/%Y"}}},
+			dataType: VT_scDateT,
+			in:       "31/12/2023",
+			want:     time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "transforms in declared order",
+			source: FieldSource{Transform: TransformSlice{
+				{Regex: `^id-(.*)$`},
+				{Separator: "-"},
+			}},
+			dataType: VT_scText,
+			in:       "id-1-2",
+			want:     []interface{}{"1", "2"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			field := Field{ID: "f", DataType: NewSingleDataType(c.dataType), Source: c.source}
+			transformer, err := newValueTransformer(field)
+			if err != nil {
+				t.Fatalf("newValueTransformer: %v", err)
+			}
+			got, err := transformer.Apply(c.in)
+			if err != nil {
+				t.Fatalf("Apply(%q): %v", c.in, err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Apply(%q) = %#v, want %#v", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestValueTransformerNoMatch(t *testing.T) {
+	field := Field{ID: "f", DataType: NewSingleDataType(VT_scText), Source: FieldSource{
+		Transform: TransformSlice{{Regex: `^(train|val)$`}},
+	}}
+	transformer, err := newValueTransformer(field)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transformer.Apply("test"); err == nil {
+		t.Error("expected an error for a value that does not match the regex")
+	}
+}
+
+func TestValueTransformerFormatOnNonDateField(t *testing.T) {
+	field := csvTestField("year", VT_scInt)
+	field.Source.Format = "%Y"
+	transformer, err := newValueTransformer(field)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := transformer.Apply("2024"); err != nil || got != "2024" {
+		t.Errorf("Apply = %v, %v, want the value unchanged", got, err)
+	}
+
+	metadata := csvTestMetadata(field)
+	want := `Field "year" has a format "%Y", which only applies to sc:Date and sc:DateTime fields, and is ignored.`
+	if report := ValidateMetadata(metadata).Report(); !strings.Contains(report, want) {
+		t.Errorf("report is missing %q:\n%s", want, report)
+	}
+}
+
+func TestTransformSliceJSON(t *testing.T) {
+	var single TransformSlice
+	if err := single.UnmarshalJSON([]byte(`{"regex": "a"}`)); err != nil || len(single) != 1 {
+		t.Fatalf("single transform: %v, %#v", err, single)
+	}
+	var multi TransformSlice
+	if err := multi.UnmarshalJSON([]byte(`[{"regex": "a"}, {"separator": ","}]`)); err != nil || len(multi) != 2 {
+		t.Fatalf("multiple transforms: %v, %#v", err, multi)
+	}
+}
@@ -2,11 +2,14 @@
 package croissant
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -16,6 +19,18 @@ type ValidationOptions struct {
 	CheckDataTypes  bool
 	ValidateURLs    bool
 	CheckFileExists bool
+	// Recompute the checksums and size of local FileObjects and report mismatches.
+	VerifyChecksums bool
+	// Read the data of every record set, report values that do not match their field's dataType,
+	// and check that record set keys are non-null and unique.
+	CheckData bool
+	// Directory that relative content URLs are resolved against when checking files.
+	// Defaults to the current working directory.
+	BaseDir string
+	// Never fetch remote JSON-LD contexts; only the embedded Croissant contexts are available.
+	Offline bool
+	// Warn when recommended Responsible AI (RAI) properties, such as rai:dataBiases, are missing.
+	StrictRAI bool
 }
 
 // DefaultValidationOptions returns default validation options.
@@ -25,6 +40,11 @@ func DefaultValidationOptions() ValidationOptions {
 		CheckDataTypes:  true,
 		ValidateURLs:    false, // Don't validate URLs by default to avoid network calls
 		CheckFileExists: false, // Don't check file existence by default
+		VerifyChecksums: false, // Don't hash files by default
+		CheckData:       false, // Don't read data files by default
+		BaseDir:         "",
+		Offline:         false, // Fetch JSON-LD contexts that are not embedded
+		StrictRAI:       false, // RAI properties are optional in Croissant
 	}
 }
 
@@ -60,7 +80,7 @@ func ValidateJSON(data []byte) (*Issues, error) {
 // ValidateJSONWithOptions validates Croissant metadata in JSON-LD format with options and returns issues.
 func ValidateJSONWithOptions(data []byte, options ValidationOptions) (*Issues, error) {
 	// Use JSON-LD processor for proper validation and parsing
-	processor := NewJSONLDProcessor()
+	processor := NewJSONLDProcessorWithOptions(JSONLDOptions{Offline: options.Offline})
 
 	// First, validate that it's valid JSON-LD
 	if err := processor.ValidateJSONLD(data); err != nil {
@@ -122,6 +142,19 @@ func ValidateMetadataNode(node *MetadataNode, issues *Issues, options Validation
 		}
 	}
 
+	// Properties outside of the Croissant vocabulary
+	validateUncompactedProperties(node, issues)
+
+	// Creators and publishers
+	validateAgents(node, "creator", node.metadata.Creator, issues)
+	validateAgents(node, "publisher", node.metadata.Publisher, issues)
+
+	// Keywords
+	validateKeywords(node, node.metadata.Keywords, issues)
+
+	// Responsible AI properties
+	validateRAI(node, issues, options)
+
 	// Validate distributions
 	if len(node.Distributions) == 0 {
 		issues.AddError("Dataset must have at least one distribution.", node)
@@ -142,6 +175,13 @@ func ValidateMetadataNode(node *MetadataNode, issues *Issues, options Validation
 		ValidateRecordSetNode(rs, issues, options)
 	}
 
+	// Data conformance validation
+	if options.CheckData {
+		for _, rs := range node.RecordSets {
+			checkRecordSetData(node, rs, issues, options)
+		}
+	}
+
 	// Cross-references validation
 	ValidateCrossReferences(node, issues)
 }
@@ -157,8 +197,13 @@ func ValidateDistributionNode(dist *DistributionNode, issues *Issues, options Va
 		issues.AddError(fmt.Sprintf("\"%s\" should have an attribute \"@type\": \"http://mlcommons.org/croissant/FileObject\" or \"@type\": \"http://mlcommons.org/croissant/FileSet\". Got %s instead.", dist.Name, dist.Type), dist)
 	}
 
+	isFileSet := dist.Type == "cr:FileSet"
+
 	if dist.ContentURL == "" {
-		issues.AddError("Property \"https://schema.org/contentUrl\" is mandatory, but does not exist.", dist)
+		// FileSets locate their files through containedIn and includes instead
+		if dist.needsContentURL() {
+			issues.AddError("Property \"https://schema.org/contentUrl\" is mandatory, but does not exist.", dist)
+		}
 	} else if options.ValidateURLs && !isValidURL(dist.ContentURL) {
 		issues.AddError(fmt.Sprintf("ContentURL \"%s\" is not a valid URL.", dist.ContentURL), dist)
 	}
@@ -169,6 +214,19 @@ func ValidateDistributionNode(dist *DistributionNode, issues *Issues, options Va
 		issues.AddWarning(fmt.Sprintf("EncodingFormat \"%s\" is not a recognized MIME type.", dist.EncodingFormat), dist)
 	}
 
+	if isFileSet {
+		if dist.Includes == "" {
+			issues.AddError("Property \"http://mlcommons.org/croissant/includes\" is mandatory for FileSets, but does not exist.", dist)
+		} else if _, err := compileGlob(dist.Includes); err != nil {
+			issues.AddError(fmt.Sprintf("Includes glob \"%s\" is not valid.", dist.Includes), dist)
+		}
+		if dist.Excludes != "" {
+			if _, err := compileGlob(dist.Excludes); err != nil {
+				issues.AddError(fmt.Sprintf("Excludes glob \"%s\" is not valid.", dist.Excludes), dist)
+			}
+		}
+	}
+
 	// Hash validation
 	if dist.SHA256 != "" && !isValidSHA256(dist.SHA256) {
 		issues.AddError(fmt.Sprintf("SHA256 hash \"%s\" is not a valid SHA-256 hash.", dist.SHA256), dist)
@@ -181,11 +239,64 @@ func ValidateDistributionNode(dist *DistributionNode, issues *Issues, options Va
 	}
 
 	// File existence check
-	if options.CheckFileExists && isLocalFile(dist.ContentURL) {
-		if _, err := os.Stat(dist.ContentURL); os.IsNotExist(err) {
-			issues.AddWarning(fmt.Sprintf("File \"%s\" does not exist.", dist.ContentURL), dist)
+	if options.CheckFileExists {
+		if isFileSet {
+			validateFileSetFiles(dist, issues, options)
+		} else if isLocalFile(dist.ContentURL) {
+			contentPath := dist.ContentURL
+			if options.BaseDir != "" && !filepath.IsAbs(contentPath) {
+				contentPath = filepath.Join(options.BaseDir, contentPath)
+			}
+			if _, err := os.Stat(contentPath); os.IsNotExist(err) {
+				issues.AddWarning(fmt.Sprintf("File \"%s\" does not exist.", dist.ContentURL), dist)
+			}
 		}
 	}
+
+	// Checksum and size verification
+	if options.VerifyChecksums && !isFileSet {
+		verifyDistributionFile(dist, issues, options)
+	}
+}
+
+// verifyDistributionFile recomputes the checksums and size of a local FileObject
+// and reports every mismatch with its metadata. Remote files are not verified.
+func verifyDistributionFile(dist *DistributionNode, issues *Issues, options ValidationOptions) {
+	parent, ok := dist.GetParent().(*MetadataNode)
+	if !ok {
+		return
+	}
+	distribution := findDistribution(parent.metadata, dist.ID)
+	if distribution == nil || (distribution.ContainedIn == nil && !isLocalFile(distribution.ContentURL)) {
+		return
+	}
+	if distribution.SHA256 == "" && distribution.MD5 == "" && distribution.ContentSize == "" {
+		return
+	}
+
+	mismatches, _, err := VerifyFileObject(context.Background(), parent.metadata, *distribution, options.BaseDir)
+	if err != nil {
+		issues.AddWarning(fmt.Sprintf("File \"%s\" could not be verified: %v", dist.ContentURL, err), dist)
+		return
+	}
+	for _, mismatch := range mismatches {
+		issues.AddError(mismatch, dist)
+	}
+}
+
+// validateFileSetFiles checks that a FileSet resolves to at least one local file.
+func validateFileSetFiles(dist *DistributionNode, issues *Issues, options ValidationOptions) {
+	parent, ok := dist.GetParent().(*MetadataNode)
+	if !ok {
+		return
+	}
+
+	files, err := ResolveFileSet(parent.metadata, dist.ID, options.BaseDir)
+	if err != nil {
+		issues.AddWarning(fmt.Sprintf("Files of FileSet \"%s\" could not be resolved: %v", dist.Name, err), dist)
+	} else if len(files) == 0 {
+		issues.AddWarning(fmt.Sprintf("FileSet \"%s\" does not match any files.", dist.Name), dist)
+	}
 }
 
 // ValidateRecordSetNode validates a record set node.
@@ -207,12 +318,39 @@ func ValidateRecordSetNode(rs *RecordSetNode, issues *Issues, options Validation
 		validateRecordSetKey(rs, issues)
 	}
 
+	if len(rs.Data) > 0 {
+		validateRecordSetData(rs, issues)
+	}
+
 	for _, field := range rs.Fields {
 		field.SetParent(rs)
 		ValidateFieldNode(field, issues, options)
 	}
 }
 
+// validateRecordSetData validates that the rows of inline data only hold values of existing fields.
+func validateRecordSetData(rs *RecordSetNode, issues *Issues) {
+	fieldIDs := make(map[string]bool)
+	for _, field := range rs.Fields {
+		if field.ID != "" {
+			fieldIDs[field.ID] = true
+		}
+		if field.Name != "" {
+			fieldIDs[field.Name] = true
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, row := range rs.Data {
+		for _, key := range slices.Sorted(maps.Keys(row)) {
+			if !fieldIDs[key] && !reported[key] {
+				reported[key] = true
+				issues.AddError(fmt.Sprintf("Inline data references non-existent field \"%s\"", key), rs)
+			}
+		}
+	}
+}
+
 // validateRecordSetKey validates that key references point to existing fields.
 func validateRecordSetKey(rs *RecordSetNode, issues *Issues) {
 	if rs.Key == nil {
@@ -225,20 +363,9 @@ func validateRecordSetKey(rs *RecordSetNode, issues *Issues) {
 		return
 	}
 
-	// Build a map of available field IDs for this record set
-	fieldIDs := make(map[string]bool)
-	for _, field := range rs.Fields {
-		if field.ID != "" {
-			fieldIDs[field.ID] = true
-		}
-		if field.Name != "" {
-			fieldIDs[field.Name] = true
-		}
-	}
-
 	// Check that all key IDs reference existing fields
 	for _, keyID := range keyIDs {
-		if !fieldIDs[keyID] {
+		if keyFieldNode(rs, keyID) == nil {
 			if rs.Key.IsComposite() {
 				issues.AddError(fmt.Sprintf("Composite key references non-existent field \"%s\"", keyID), rs)
 			} else {
@@ -283,14 +410,50 @@ func ValidateFieldNode(field *FieldNode, issues *Issues, options ValidationOptio
 	// Check if field has subfields - use len check that's safe even if SubFields is nil
 	hasSubFields := field.SubField != nil && len(field.SubField) > 0
 
-	// Only validate source for leaf fields (fields without subfields)
-	if !hasSubFields {
+	// Only validate source for leaf fields (fields without subfields) that are not read from inline data
+	if !hasSubFields && !hasInlineData(field) {
 		// Check if source is properly configured
-		if !hasValidFieldSource(field) {
+		fileProperty := field.Source.Extract.FileProperty
+		if fileProperty != "" && !IsValidFileProperty(fileProperty) {
+			issues.AddError(fmt.Sprintf("Field \"%s\" has invalid fileProperty \"%s\". Valid properties are: fullpath, filename, content, lines, lineNumbers.", field.Name, fileProperty), field)
+		} else if !hasValidFieldSource(field) {
 			issues.AddError(fmt.Sprintf("Field \"%s\" has invalid or missing source configuration.", field.Name), field)
 		}
 	}
 
+	// Check that JSONPath expressions and transforms can be compiled
+	if field.Source.Extract.JSONPath != "" {
+		if _, err := compileJSONPath(field.Source.Extract.JSONPath); err != nil {
+			issues.AddError(fmt.Sprintf("Field \"%s\" has invalid jsonPath \"%s\".", field.Name, field.Source.Extract.JSONPath), field)
+		}
+	}
+	for _, transform := range field.Source.Transform {
+		if transform.JSONPath != "" {
+			if _, err := compileJSONPath(transform.JSONPath); err != nil {
+				issues.AddError(fmt.Sprintf("Field \"%s\" has invalid transform jsonPath \"%s\".", field.Name, transform.JSONPath), field)
+			}
+		}
+		if transform.Regex == "" {
+			continue
+		}
+		if _, err := regexp.Compile(transform.Regex); err != nil {
+			issues.AddError(fmt.Sprintf("Field \"%s\" has invalid transform regex \"%s\": %v", field.Name, transform.Regex, err), field)
+		}
+	}
+
+	// Formats are date layouts, and are ignored when reading other values
+	if !isDateType(field.DataType) {
+		formats := []string{field.Source.Format}
+		for _, transform := range field.Source.Transform {
+			formats = append(formats, transform.Format)
+		}
+		for _, format := range formats {
+			if format != "" {
+				issues.AddWarning(fmt.Sprintf("Field \"%s\" has a format \"%s\", which only applies to sc:Date and sc:DateTime fields, and is ignored.", field.Name, format), field)
+			}
+		}
+	}
+
 	// Validate subfields recursively
 	if field.SubField != nil {
 		for _, subField := range field.SubField {
@@ -302,17 +465,23 @@ func ValidateFieldNode(field *FieldNode, issues *Issues, options ValidationOptio
 	}
 }
 
+// hasInlineData checks if a field node belongs to a record set with inline data.
+func hasInlineData(field *FieldNode) bool {
+	recordSet, ok := field.GetParent().(*RecordSetNode)
+	return ok && len(recordSet.Data) > 0
+}
+
 // hasValidFieldSource checks if a field node has valid source configuration.
 func hasValidFieldSource(field *FieldNode) bool {
 	if field == nil {
 		return false
 	}
 
-	// Check if the source has file object reference and extraction method
-	hasFileObject := field.Source.FileObject.ID != ""
+	// Check if the source has file object (or file set) reference and extraction method
+	hasFileObject := field.Source.FileObject.ID != "" || field.Source.FileSet.ID != ""
 	hasExtract := field.Source.Extract.Column != "" ||
 		field.Source.Extract.JSONPath != "" ||
-		field.Source.Extract.FileProperty != "" ||
+		IsValidFileProperty(field.Source.Extract.FileProperty) ||
 		field.Source.Extract.Regex != ""
 	hasFormat := field.Source.Format != ""
 
@@ -354,7 +523,7 @@ func ValidateCrossReferences(node *MetadataNode, issues *Issues) {
 		}
 	}
 
-	// Check field sources reference valid file objects
+	// Check field sources reference valid file objects and file sets
 	for _, rs := range node.RecordSets {
 		for _, field := range rs.Fields {
 			if field.Source.FileObject.ID != "" {
@@ -362,8 +531,91 @@ func ValidateCrossReferences(node *MetadataNode, issues *Issues) {
 					issues.AddError(fmt.Sprintf("Field \"%s\" references non-existent file object \"%s\".", field.Name, field.Source.FileObject.ID), field)
 				}
 			}
+			if field.Source.FileSet.ID != "" {
+				if !availableIDs[field.Source.FileSet.ID] {
+					issues.AddError(fmt.Sprintf("Field \"%s\" references non-existent file set \"%s\".", field.Name, field.Source.FileSet.ID), field)
+				}
+			}
 		}
 	}
+
+	// Check distributions are contained in existing distributions
+	for _, dist := range node.Distributions {
+		if dist.ContainedIn != nil && dist.ContainedIn.ID != "" && !availableIDs[dist.ContainedIn.ID] {
+			issues.AddError(fmt.Sprintf("Distribution \"%s\" is contained in non-existent distribution \"%s\".", dist.Name, dist.ContainedIn.ID), dist)
+		}
+	}
+
+	// Check field references point to existing fields of compatible types
+	for _, rs := range node.RecordSets {
+		validateFieldReferences(node, rs.Fields, issues)
+	}
+}
+
+// validateFieldReferences checks that the references and parentField of fields, and of
+// their subfields, name existing fields whose dataType is compatible with theirs.
+func validateFieldReferences(node *MetadataNode, fields []*FieldNode, issues *Issues) {
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+
+		for _, property := range []struct {
+			name    string
+			verb    string
+			missing string
+			refs    FieldRefSlice
+		}{
+			{"references", "references", "Field \"%s\" references non-existent field \"%s\".", field.References},
+			{"parentField", "has parentField", "Field \"%s\" has parentField \"%s\" which does not exist.", field.ParentField},
+		} {
+			for _, ref := range property.refs {
+				targetID := ref.FieldID()
+				if targetID == "" {
+					issues.AddError(fmt.Sprintf("Field \"%s\" has a %s without a field ID.", field.Name, property.name), field)
+					continue
+				}
+				targetDataType, exists := referencedFieldDataType(node, targetID)
+				if !exists {
+					issues.AddError(fmt.Sprintf(property.missing, field.Name, targetID), field)
+					continue
+				}
+
+				sourceType, _ := resolveValueType(field.DataType)
+				targetType, _ := resolveValueType(targetDataType)
+				if !areKeyTypesCompatible(sourceType, targetType) {
+					issues.AddError(fmt.Sprintf("Field \"%s\" of type %s %s field \"%s\" of incompatible type %s.", field.Name, sourceType, property.verb, targetID, targetType), field)
+				}
+			}
+		}
+
+		validateFieldReferences(node, field.SubField, issues)
+	}
+}
+
+// referencedFieldDataType returns the data type of the field or subField a reference points to.
+func referencedFieldDataType(node *MetadataNode, fieldID string) (DataType, bool) {
+	for _, rs := range node.RecordSets {
+		if target := findFieldNode(rs.Fields, fieldID); target != nil {
+			return target.DataType, true
+		}
+	}
+	if _, target := FindField(node.metadata, fieldID); target != nil {
+		return target.DataType, true
+	}
+
+	return nil, false
+}
+
+// areKeyTypesCompatible checks if values of two value types can be matched as keys.
+// Integers and numbers are compatible with each other.
+func areKeyTypesCompatible(sourceType string, targetType string) bool {
+	if sourceType == targetType {
+		return true
+	}
+
+	numeric := []string{VT_scInt, VT_scFloat, VT_scNum}
+	return slices.Contains(numeric, sourceType) && slices.Contains(numeric, targetType)
 }
 
 // AddValidationToMetadata adds validation functionality to the Metadata struct.
@@ -468,6 +720,7 @@ func isValidEncodingFormat(format string) bool {
 		"application/xml":           true,
 		"text/xml":                  true,
 		"application/parquet":       true,
+		"application/x-parquet":     true,
 		"text/tab-separated-values": true,
 		"application/zip":           true,
 		"application/gzip":          true,
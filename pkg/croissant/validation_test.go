@@ -4,6 +4,7 @@ package croissant
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -58,3 +59,79 @@ func TestValidateBadCroissantFilesInDir(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateInvalidReferences(t *testing.T) {
+	cases := map[string][]string{
+		"invalid_references.jsonld": {
+			`[Metadata(mydataset) > RecordSet(a-record-set) > Field(first-field)] Field "first-field" references non-existent file object "NON_EXISTENT_TABLE".`,
+		},
+		"invalid_field_references.jsonld": {
+			`[Metadata(mydataset) > RecordSet(annotations) > Field(image_id)] Field "image_id" references non-existent field "images/image_id".`,
+			`[Metadata(mydataset) > RecordSet(annotations) > Field(label)] Field "label" of type sc:Text references field "labels/id" of incompatible type sc:Integer.`,
+			`[Metadata(mydataset) > RecordSet(labels) > Field(parent)] Field "parent" has parentField "labels/parent_id" which does not exist.`,
+		},
+	}
+	for name, want := range cases {
+		issues, err := ValidateFile(filepath.Join("testdata/1.0/bad", name))
+		if err != nil {
+			t.Fatalf("Failed to load croissant file %s: %v", name, err)
+		}
+		report := issues.Report()
+		for _, message := range want {
+			if !strings.Contains(report, message) {
+				t.Errorf("%s: report is missing %q:\n%s", name, message, report)
+			}
+		}
+	}
+}
+
+func TestValidateReferencesToSubFields(t *testing.T) {
+	location := csvTestField("location", VT_scText)
+	location.Source = FieldSource{}
+	location.SubField = []Field{csvTestField("city", VT_scText)}
+	location.SubField[0].ID = "main/location/city"
+	capital := csvTestField("capital", VT_scText)
+	capital.References = FieldRefSlice{{ID: "main/location/city"}}
+	region := csvTestField("region", VT_scText)
+	region.ParentField = FieldRefSlice{{ID: "main/location/city"}}
+	metadata := csvTestMetadata(location, capital, region)
+
+	report := ValidateMetadata(metadata).Report()
+	if strings.Contains(report, "main/location/city") {
+		t.Errorf("references to a subField should resolve:\n%s", report)
+	}
+}
+
+func TestValidateFileSets(t *testing.T) {
+	issues, err := ValidateFile("testdata/1.0/good/coco2014-mini.jsonld")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := issues.Report(); strings.Contains(report, "(image-files)] Property") {
+		t.Errorf("FileSet contained in an archive should not need a contentUrl:\n%s", report)
+	}
+
+	metadata := fileSetTestMetadata()
+	metadata.Distributions[1].Includes = ""
+	want := `[Metadata(images) > FileObject(image-files)] Property "http://mlcommons.org/croissant/includes" is mandatory for FileSets, but does not exist.`
+	if report := ValidateMetadata(metadata).Report(); !strings.Contains(report, want) {
+		t.Errorf("report is missing %q:\n%s", want, report)
+	}
+}
+
+func TestValidateRecordSetRelativeKeys(t *testing.T) {
+	issues, err := ValidateFile("testdata/1.0/good/coco2014-mini.jsonld")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := issues.Report()
+	for _, keyID := range []string{"id", "name"} {
+		if strings.Contains(report, `Key references non-existent field "`+keyID+`"`) {
+			t.Errorf("key %q relative to its record set should resolve:\n%s", keyID, report)
+		}
+	}
+	// The images record set has no img_id field at all.
+	if want := `[Metadata(Mini-COCO) > RecordSet(images)] Key references non-existent field "img_id"`; !strings.Contains(report, want) {
+		t.Errorf("report is missing %q:\n%s", want, report)
+	}
+}
@@ -0,0 +1,452 @@
+// value_types.go
+// Maps data types to the Go values that record values are converted to.
+package croissant
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValueType describes how values of a data type are represented in Go,
+// parsed from their text form and formatted back to text.
+type ValueType struct {
+	// Go type of converted values.
+	GoType reflect.Type
+	// Parse converts the text form of a value. The text is trimmed, except for sc:Text.
+	Parse func(text string) (interface{}, error)
+	// Format returns the text form of a converted value.
+	Format func(value interface{}) (string, error)
+	// Convert converts values that are neither text nor of GoType, such as lists
+	// of coordinates decoded from JSON. Optional: by default, their text form is parsed.
+	Convert func(value interface{}) (interface{}, error)
+	// List reports that a single value is a list of numbers, such as a bounding box,
+	// so that lists are converted as a whole rather than as repeated values.
+	List bool
+	// Infer reports whether InferDataType infers a trimmed value as of this type, for the
+	// types of inferredDataTypes. Optional: by default, values that Parse accepts are.
+	Infer func(text string) bool
+}
+
+// GeoCoordinates is the value of an sc:GeoCoordinates field.
+type GeoCoordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// String returns the coordinates as "latitude,longitude".
+func (g GeoCoordinates) String() string {
+	return formatFloat(g.Latitude) + "," + formatFloat(g.Longitude)
+}
+
+// BoundingBox is the value of a cr:BoundingBox field: four coordinates in the order
+// of the source data, usually x, y, width and height.
+type BoundingBox [4]float64
+
+// String returns the coordinates separated by commas.
+func (b BoundingBox) String() string {
+	parts := make([]string, len(b))
+	for i, coordinate := range b {
+		parts[i] = formatFloat(coordinate)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+var (
+	valueTypesMu sync.RWMutex            //nolint:gochecknoglobals
+	valueTypes   = map[string]ValueType{ //nolint:gochecknoglobals
+		VT_scText:     {GoType: reflect.TypeFor[string](), Parse: parseText, Format: formatText},
+		VT_scInt:      {GoType: reflect.TypeFor[int64](), Parse: parseInteger, Format: formatInteger},
+		VT_scFloat:    {GoType: reflect.TypeFor[float64](), Parse: parseFloat, Format: formatFloatValue},
+		VT_scNum:      {GoType: reflect.TypeFor[float64](), Parse: parseFloat, Format: formatFloatValue},
+		VT_scBool:     {GoType: reflect.TypeFor[bool](), Parse: parseBoolean, Format: formatBoolean, Infer: inferBoolean},
+		VT_scDate:     {GoType: reflect.TypeFor[time.Time](), Parse: parseDate, Format: timeFormatter(time.DateOnly)},
+		VT_scDateT:    {GoType: reflect.TypeFor[time.Time](), Parse: parseDate, Format: timeFormatter(time.RFC3339), Infer: inferDate},
+		VT_scURL:      {GoType: reflect.TypeFor[*url.URL](), Parse: parseURL, Format: formatURL, Infer: inferURL},
+		VT_scGeoCoord: {GoType: reflect.TypeFor[GeoCoordinates](), Parse: parseGeoCoordinates, Format: formatStringer, Convert: convertGeoCoordinates, List: true},
+		VT_crBBox:     {GoType: reflect.TypeFor[BoundingBox](), Parse: parseBoundingBox, Format: formatStringer, Convert: convertBoundingBox, List: true},
+	}
+)
+
+// inferredDataTypes are the data types InferDataType tries, in order. Other values are text.
+var inferredDataTypes = []string{VT_scBool, VT_scInt, VT_scNum, VT_scDateT, VT_scURL} //nolint:gochecknoglobals
+
+// RegisterValueType registers how values of a data type are converted, replacing
+// any previous registration. Custom types (e.g. "wd:Q..." entities) can be
+// registered so that record values of fields with that dataType are converted.
+func RegisterValueType(dataType string, valueType ValueType) {
+	valueTypesMu.Lock()
+	defer valueTypesMu.Unlock()
+	valueTypes[dataType] = valueType
+}
+
+// LookupValueType returns the value type registered for a data type.
+func LookupValueType(dataType string) (ValueType, bool) {
+	valueTypesMu.RLock()
+	defer valueTypesMu.RUnlock()
+	valueType, exists := valueTypes[dataType]
+
+	return valueType, exists
+}
+
+// resolveValueType returns the first type of a DataType that has a registered value type.
+// Semantic types such as Wikidata entities or cr:Label are skipped; values default to sc:Text.
+func resolveValueType(dataType DataType) (string, ValueType) {
+	for _, typ := range dataType.GetTypes() {
+		if valueType, exists := LookupValueType(typ); exists {
+			return typ, valueType
+		}
+	}
+	valueType, _ := LookupValueType(VT_scText)
+
+	return VT_scText, valueType
+}
+
+// inferValueType returns the first of inferredDataTypes whose registered value type infers a value.
+func inferValueType(text string) (string, bool) {
+	for _, dataType := range inferredDataTypes {
+		valueType, exists := LookupValueType(dataType)
+		if !exists {
+			continue
+		}
+		if valueType.Infer != nil {
+			if valueType.Infer(text) {
+				return dataType, true
+			}
+		} else if _, err := valueType.Parse(text); err == nil {
+			return dataType, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseValue parses the text form of a value according to a DataType.
+// Empty text of non-text types is returned as nil.
+func ParseValue(text string, dataType DataType) (interface{}, error) {
+	return convertValue(text, dataType)
+}
+
+// FormatValue returns the text form of a value according to a DataType.
+// Nil values are formatted as empty text.
+func FormatValue(value interface{}, dataType DataType) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	_, valueType := resolveValueType(dataType)
+
+	return valueType.Format(value)
+}
+
+// convertValue converts a raw extracted value to the Go type of a field's DataType.
+// Repeated values are converted element by element.
+// Empty values of non-text types are returned as nil.
+func convertValue(raw interface{}, dataType DataType) (interface{}, error) {
+	typ, valueType := resolveValueType(dataType)
+
+	if items, ok := raw.([]interface{}); ok && !isSingleListValue(items, valueType) {
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			value, err := convertValue(item, dataType)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+
+	switch typed := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if typ != VT_scText {
+			typed = strings.TrimSpace(typed)
+			if typed == "" {
+				return nil, nil
+			}
+		}
+		return valueType.Parse(typed)
+	case json.Number:
+		// JSON numbers are converted like text.
+		return valueType.Parse(typed.String())
+	}
+
+	if valueType.GoType != nil && reflect.TypeOf(raw) == valueType.GoType {
+		return raw, nil
+	}
+	if valueType.Convert != nil {
+		return valueType.Convert(raw)
+	}
+	if typ == VT_scText {
+		// Values of other types (e.g. []byte content) are kept as read.
+		return raw, nil
+	}
+
+	return valueType.Parse(stringifyValue(raw))
+}
+
+// isSingleListValue returns true if a list is a single value of a list type,
+// such as the four coordinates of a bounding box, rather than repeated values.
+func isSingleListValue(items []interface{}, valueType ValueType) bool {
+	if !valueType.List {
+		return false
+	}
+	for _, item := range items {
+		switch item.(type) {
+		case []interface{}, map[string]interface{}:
+			return false
+		}
+	}
+
+	return len(items) > 0
+}
+
+func parseText(text string) (interface{}, error) {
+	return text, nil
+}
+
+func formatText(value interface{}) (string, error) {
+	return stringifyValue(value), nil
+}
+
+func parseInteger(text string) (interface{}, error) {
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return nil, CroissantError{Message: "value is not an integer", Value: text}
+	}
+
+	return value, nil
+}
+
+func formatInteger(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case int:
+		return strconv.Itoa(typed), nil
+	default:
+		return "", CroissantError{Message: "value is not an integer", Value: value}
+	}
+}
+
+func parseFloat(text string) (interface{}, error) {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, CroissantError{Message: "value is not a number", Value: text}
+	}
+
+	return value, nil
+}
+
+func formatFloatValue(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case float64:
+		return formatFloat(typed), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	default:
+		return "", CroissantError{Message: "value is not a number", Value: value}
+	}
+}
+
+// formatFloat returns the shortest text form of a float without an exponent.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func parseBoolean(text string) (interface{}, error) {
+	value, err := strconv.ParseBool(text)
+	if err != nil {
+		return nil, CroissantError{Message: "value is not a boolean", Value: text}
+	}
+
+	return value, nil
+}
+
+// inferBoolean infers only the literals true and false as booleans, so that 0 and 1 stay integers.
+func inferBoolean(text string) bool {
+	lower := strings.ToLower(text)
+	return lower == "true" || lower == "false"
+}
+
+func formatBoolean(value interface{}) (string, error) {
+	typed, ok := value.(bool)
+	if !ok {
+		return "", CroissantError{Message: "value is not a boolean", Value: value}
+	}
+
+	return strconv.FormatBool(typed), nil
+}
+
+// dateLayouts are the layouts that dates and timestamps are parsed with, in order.
+var dateLayouts = []string{ //nolint:gochecknoglobals
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"2006/01/02",
+}
+
+// parseDate parses a date or timestamp in one of the commonly used layouts.
+func parseDate(text string) (interface{}, error) {
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return nil, CroissantError{Message: "unrecognized date format", Value: text}
+}
+
+// inferredDateLayouts are the layouts of the values inferred as dates. Unlike parseDate,
+// a date and time separated by a space is not inferred.
+var inferredDateLayouts = []string{ //nolint:gochecknoglobals
+	"2006-01-02",
+	"01/02/2006",
+	"2006/01/02",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05-07:00",
+}
+
+// inferDate infers values in one of inferredDateLayouts as dates.
+func inferDate(text string) bool {
+	for _, layout := range inferredDateLayouts {
+		if _, err := time.Parse(layout, text); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// timeFormatter returns a Format function writing times with a layout.
+func timeFormatter(layout string) func(interface{}) (string, error) {
+	return func(value interface{}) (string, error) {
+		typed, ok := value.(time.Time)
+		if !ok {
+			return "", CroissantError{Message: "value is not a date", Value: value}
+		}
+		return typed.Format(layout), nil
+	}
+}
+
+// parseURL parses an absolute URL. Compact IRIs such as "cr:TrainingSplit" are accepted.
+func parseURL(text string) (interface{}, error) {
+	parsed, err := url.Parse(text)
+	if err != nil || parsed.Scheme == "" {
+		return nil, CroissantError{Message: "value is not an absolute URL", Value: text}
+	}
+
+	return parsed, nil
+}
+
+// inferURL infers only web URLs, as many other values parse as URLs.
+func inferURL(text string) bool {
+	if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") {
+		return false
+	}
+	_, err := url.ParseRequestURI(text)
+
+	return err == nil
+}
+
+func formatURL(value interface{}) (string, error) {
+	typed, ok := value.(*url.URL)
+	if !ok {
+		return "", CroissantError{Message: "value is not a URL", Value: value}
+	}
+
+	return typed.String(), nil
+}
+
+// formatStringer formats values through their String method.
+func formatStringer(value interface{}) (string, error) {
+	typed, ok := value.(fmt.Stringer)
+	if !ok {
+		return "", CroissantError{Message: "value cannot be formatted", Value: value}
+	}
+
+	return typed.String(), nil
+}
+
+// parseNumberList parses numbers separated by commas or spaces, optionally
+// enclosed in brackets or parentheses, e.g. "[10, 20, 30, 40]".
+func parseNumberList(text string) ([]float64, error) {
+	text = strings.Trim(strings.TrimSpace(text), "[]()")
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	numbers := make([]float64, len(parts))
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, CroissantError{Message: "value is not a list of numbers", Value: text}
+		}
+		numbers[i] = number
+	}
+
+	return numbers, nil
+}
+
+// parseGeoCoordinates parses "latitude,longitude" coordinates.
+func parseGeoCoordinates(text string) (interface{}, error) {
+	numbers, err := parseNumberList(text)
+	if err != nil || len(numbers) != 2 {
+		return nil, CroissantError{Message: "value is not a latitude and longitude", Value: text}
+	}
+	if numbers[0] < -90 || numbers[0] > 90 || numbers[1] < -180 || numbers[1] > 180 {
+		return nil, CroissantError{Message: "coordinates are out of range", Value: text}
+	}
+
+	return GeoCoordinates{Latitude: numbers[0], Longitude: numbers[1]}, nil
+}
+
+// convertGeoCoordinates converts [latitude, longitude] lists and
+// {"latitude": ..., "longitude": ...} objects.
+func convertGeoCoordinates(value interface{}) (interface{}, error) {
+	if object, ok := value.(map[string]interface{}); ok {
+		value = []interface{}{object["latitude"], object["longitude"]}
+	}
+
+	return convertNumberList(value, parseGeoCoordinates)
+}
+
+// parseBoundingBox parses the four coordinates of a bounding box.
+func parseBoundingBox(text string) (interface{}, error) {
+	numbers, err := parseNumberList(text)
+	if err != nil || len(numbers) != 4 {
+		return nil, CroissantError{Message: "value is not a bounding box of four coordinates", Value: text}
+	}
+
+	return BoundingBox(numbers), nil
+}
+
+// convertBoundingBox converts a list of four coordinates.
+func convertBoundingBox(value interface{}) (interface{}, error) {
+	return convertNumberList(value, parseBoundingBox)
+}
+
+// convertNumberList converts a list of numbers by parsing its text form.
+func convertNumberList(value interface{}, parse func(string) (interface{}, error)) (interface{}, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return parse(stringifyValue(value))
+	}
+
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = stringifyValue(item)
+	}
+
+	return parse(strings.Join(parts, ","))
+}
@@ -0,0 +1,120 @@
+// File: pkg/croissant/value_types_test.go
+package croissant
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConvertValue(t *testing.T) {
+	cases := []struct {
+		raw      interface{}
+		dataType string
+		want     interface{}
+	}{
+		{"42", VT_scInt, int64(42)},
+		{json.Number("42"), VT_scFloat, 42.0},
+		{int64(3), VT_scFloat, 3.0},
+		{" true ", VT_scBool, true},
+		{"", VT_scInt, nil},
+		{" padded ", VT_scText, " padded "},
+		{"2024-03-01", VT_scDate, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"https://example.com/a", VT_scURL, &url.URL{Scheme: "https", Host: "example.com", Path: "/a"}},
+		{"48.85, 2.35", VT_scGeoCoord, GeoCoordinates{Latitude: 48.85, Longitude: 2.35}},
+		{map[string]interface{}{"latitude": 1.5, "longitude": json.Number("2")}, VT_scGeoCoord, GeoCoordinates{Latitude: 1.5, Longitude: 2}},
+		{"[10, 20, 30, 40]", VT_crBBox, BoundingBox{10, 20, 30, 40}},
+		{[]interface{}{json.Number("1"), 2.0, int64(3), "4"}, VT_crBBox, BoundingBox{1, 2, 3, 4}},
+		{[]interface{}{[]interface{}{1.0, 2.0, 3.0, 4.0}}, VT_crBBox, []interface{}{BoundingBox{1, 2, 3, 4}}},
+		{[]interface{}{"1", "2"}, VT_scInt, []interface{}{int64(1), int64(2)}},
+	}
+	for _, c := range cases {
+		got, err := convertValue(c.raw, NewSingleDataType(c.dataType))
+		if err != nil {
+			t.Errorf("convertValue(%v, %s): %v", c.raw, c.dataType, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("convertValue(%v, %s) = %#v, want %#v", c.raw, c.dataType, got, c.want)
+		}
+	}
+
+	invalid := []struct {
+		raw      string
+		dataType string
+	}{
+		{"abc", VT_scInt},
+		{"3.5", VT_scInt},
+		{"example.com", VT_scURL},
+		{"100, 2", VT_scGeoCoord},
+		{"1,2,3", VT_crBBox},
+	}
+	for _, c := range invalid {
+		if got, err := convertValue(c.raw, NewSingleDataType(c.dataType)); err == nil {
+			t.Errorf("convertValue(%q, %s) = %v, want an error", c.raw, c.dataType, got)
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		dataType string
+		want     string
+	}{
+		{int64(7), VT_scInt, "7"},
+		{0.25, VT_scFloat, "0.25"},
+		{time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), VT_scDate, "2024-03-01"},
+		{time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), VT_scDateT, "2024-03-01T12:00:00Z"},
+		{GeoCoordinates{Latitude: 1.5, Longitude: -2}, VT_scGeoCoord, "1.5,-2"},
+		{BoundingBox{1, 2, 3.5, 4}, VT_crBBox, "1,2,3.5,4"},
+		{nil, VT_scInt, ""},
+	}
+	for _, c := range cases {
+		got, err := FormatValue(c.value, NewSingleDataType(c.dataType))
+		if err != nil {
+			t.Errorf("FormatValue(%v, %s): %v", c.value, c.dataType, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("FormatValue(%v, %s) = %q, want %q", c.value, c.dataType, got, c.want)
+		}
+	}
+
+	if _, err := FormatValue("7", NewSingleDataType(VT_scInt)); err == nil {
+		t.Error("FormatValue of text as sc:Integer should fail")
+	}
+}
+
+func TestRegisterValueType(t *testing.T) {
+	const dataType = "ex:UpperCase"
+	RegisterValueType(dataType, ValueType{
+		GoType: reflect.TypeFor[string](),
+		Parse: func(text string) (interface{}, error) {
+			return strings.ToUpper(text), nil
+		},
+		Format: formatText,
+	})
+
+	got, err := ParseValue("en", NewArrayDataType(dataType, VT_scText))
+	if err != nil || got != "EN" {
+		t.Errorf("ParseValue with a registered type = %v, %v, want EN", got, err)
+	}
+}
+
+func TestRecordJSON(t *testing.T) {
+	record := Record{
+		"url":  &url.URL{Scheme: "https", Host: "example.com"},
+		"bbox": BoundingBox{1, 2, 3, 4},
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"bbox":[1,2,3,4],"url":"https://example.com"}`; string(encoded) != want {
+		t.Errorf("json.Marshal(record) = %s, want %s", encoded, want)
+	}
+}
@@ -0,0 +1,222 @@
+// verify.go
+// Verifies the files of all distributions concurrently.
+package croissant
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// VerifyOptions represents options for verifying the files of distributions.
+type VerifyOptions struct {
+	// Directory to resolve relative content URLs against.
+	BaseDir string
+	// Number of files hashed concurrently. Values below 1 use a single worker.
+	Workers int
+	// Progress, if set, is called after each file is verified with the number of
+	// files verified so far and the total number of files. Calls are not concurrent.
+	Progress func(verified int, total int)
+}
+
+// DefaultVerifyOptions returns the default verify options, with one worker per CPU.
+func DefaultVerifyOptions() VerifyOptions {
+	return VerifyOptions{
+		Workers: runtime.NumCPU(),
+	}
+}
+
+// FileVerification is the result of verifying a single file.
+type FileVerification struct {
+	// ID of the FileObject, or of the FileSet the file belongs to.
+	Distribution string
+	// Content URL of a FileObject, or the local path of a FileSet file.
+	Path string
+	// Checksums of the file's content.
+	Checksums FileChecksums
+	// Description of each mismatch between the file and its declared sha256, md5 and contentSize.
+	Mismatches []string
+	// Remote files are not downloaded, and are reported as skipped.
+	Skipped bool
+	// Error reading the file, or resolving the files of a FileSet.
+	Err error
+}
+
+// OK reports whether the file was read and matches its declared checksums and size.
+func (v FileVerification) OK() bool {
+	return v.Err == nil && len(v.Mismatches) == 0
+}
+
+// verifyJob verifies one or more files, passing the result of each of them to report.
+type verifyJob struct {
+	files int
+	run   func(ctx context.Context, report func(FileVerification))
+}
+
+// VerifyDistributions hashes the local files of every distribution, including the files
+// matched by FileSets, and compares FileObjects with their declared sha256, md5 and contentSize.
+// Files are hashed concurrently by options.Workers workers. The members of an archive are read
+// by a single worker, as an archive can only be read from start to end.
+//
+// Results are returned in the order of the distributions, then of the files of each FileSet.
+// The error is only set if ctx is canceled; errors reading a file are reported in its result.
+//
+// Example:
+//
+//	options := croissant.DefaultVerifyOptions()
+//	options.BaseDir = "data"
+//	results, err := croissant.VerifyDistributions(ctx, *metadata, options)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, result := range results {
+//		if !result.OK() {
+//			fmt.Println(result.Path, result.Mismatches, result.Err)
+//		}
+//	}
+func VerifyDistributions(ctx context.Context, metadata Metadata, options VerifyOptions) ([]FileVerification, error) {
+	jobs := verifyJobs(metadata, options.BaseDir)
+	total := 0
+	for _, job := range jobs {
+		total += job.files
+	}
+
+	var mu sync.Mutex
+	verified := 0
+	jobResults := make([][]FileVerification, len(jobs))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(options.Workers, 1), len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				jobs[i].run(ctx, func(result FileVerification) {
+					mu.Lock()
+					defer mu.Unlock()
+					jobResults[i] = append(jobResults[i], result)
+					verified++
+					if options.Progress != nil {
+						options.Progress(verified, max(total, verified))
+					}
+				})
+			}
+		}()
+	}
+
+	for i := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var results []FileVerification
+	for _, jobResult := range jobResults {
+		results = append(results, jobResult...)
+	}
+
+	return results, nil
+}
+
+// verifyJobs lists the files to verify. FileObjects and the local files of FileSets are
+// verified by a job each; the members of an archive by a single job for their FileSet.
+func verifyJobs(metadata Metadata, baseDir string) []verifyJob {
+	var jobs []verifyJob
+	for _, dist := range metadata.Distributions {
+		if dist.Type != "cr:FileSet" {
+			if !isDirectoryDistribution(dist, baseDir) {
+				jobs = append(jobs, verifyFileObjectJob(metadata, dist, baseDir))
+			}
+			continue
+		}
+
+		files, err := ResolveFileSet(metadata, dist.ID, baseDir)
+		if err != nil {
+			result := FileVerification{Distribution: dist.ID, Path: dist.ContentURL, Err: err}
+			jobs = append(jobs, verifyJob{files: 1, run: func(_ context.Context, report func(FileVerification)) {
+				report(result)
+			}})
+			continue
+		}
+
+		archived := 0
+		for _, file := range files {
+			if file.Archive != "" {
+				archived++
+				continue
+			}
+			jobs = append(jobs, verifyJob{files: 1, run: func(_ context.Context, report func(FileVerification)) {
+				report(verifyFileEntry(dist.ID, file))
+			}})
+		}
+		if archived > 0 {
+			jobs = append(jobs, verifyArchiveMembersJob(metadata, dist.ID, baseDir, archived))
+		}
+	}
+
+	return jobs
+}
+
+// isDirectoryDistribution reports whether a FileObject is a local directory holding the files of FileSets.
+func isDirectoryDistribution(dist Distribution, baseDir string) bool {
+	if dist.ContainedIn != nil && dist.ContainedIn.ID != "" {
+		return false
+	}
+	localPath, err := resolveContentPath(dist, baseDir)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(localPath)
+
+	return err == nil && info.IsDir()
+}
+
+// verifyFileObjectJob verifies the content of a FileObject. Remote files are skipped.
+func verifyFileObjectJob(metadata Metadata, dist Distribution, baseDir string) verifyJob {
+	return verifyJob{files: 1, run: func(ctx context.Context, report func(FileVerification)) {
+		result := FileVerification{Distribution: dist.ID, Path: dist.ContentURL}
+		if (dist.ContainedIn == nil || dist.ContainedIn.ID == "") && !isLocalFile(dist.ContentURL) {
+			result.Skipped = true
+			report(result)
+			return
+		}
+		result.Mismatches, result.Checksums, result.Err = VerifyFileObject(ctx, metadata, dist, baseDir)
+		report(result)
+	}}
+}
+
+// verifyArchiveMembersJob hashes the members of the archives of a FileSet, in archive order.
+func verifyArchiveMembersJob(metadata Metadata, distributionID string, baseDir string, files int) verifyJob {
+	return verifyJob{files: files, run: func(ctx context.Context, report func(FileVerification)) {
+		for file, err := range FileSetEntries(ctx, metadata, distributionID, baseDir) {
+			if err != nil {
+				report(FileVerification{Distribution: distributionID, Err: err})
+				return
+			}
+			if file.Archive != "" {
+				report(verifyFileEntry(distributionID, file))
+			}
+		}
+	}}
+}
+
+// verifyFileEntry hashes a file of a FileSet. FileSets declare no checksums, so there are no mismatches.
+func verifyFileEntry(distributionID string, file FileEntry) FileVerification {
+	result := FileVerification{Distribution: distributionID, Path: file.FullPath}
+	content, err := file.Open()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer content.Close()
+
+	result.Checksums, result.Err = ComputeChecksums(content)
+	return result
+}